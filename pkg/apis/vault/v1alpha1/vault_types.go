@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"sort"
 	"strings"
@@ -200,6 +201,13 @@ type VaultSpec struct {
 	// default: ""
 	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
 
+	// PerInstanceServiceType is a Kubernetes Service type of the per-instance Vault Services, ClusterIP or LoadBalancer.
+	// With LoadBalancer every Vault instance advertises the address of its own LoadBalancer as
+	// api_addr and cluster_addr, unless they are set in the config, this allows multiple Vault instances
+	// per cluster in multi-cluster deployments. A Pod is replaced when the address of its LoadBalancer changes.
+	// default: ClusterIP
+	PerInstanceServiceType string `json:"perInstanceServiceType,omitempty"`
+
 	// serviceRegistrationEnabled enables the injection of the service_registration Vault stanza.
 	// This requires elaborated RBAC privileges for updating Pod labels for the Vault Pod.
 	// default: false
@@ -216,6 +224,76 @@ type VaultSpec struct {
 	// default: ""
 	RaftLeaderApiSchemeOverride string `json:"raftLeaderApiSchemeOverride,omitempty"`
 
+	// RaftRetryJoinEnabled injects a retry_join stanza per Vault instance into the raft storage configuration,
+	// pointing to the per-instance Services and the operator managed TLS CA, so Vault nodes can rejoin the raft
+	// cluster on their own after restarts. The Bank Vaults sidecar still initializes the first instance.
+	// default: false
+	RaftRetryJoinEnabled bool `json:"raftRetryJoinEnabled,omitempty"`
+
+	// RaftRecovery starts the recovery of a raft cluster which has lost its quorum. The operator writes a
+	// raft/peers.json file onto the surviving instances and restarts them, then clears this field when done.
+	// The progress is reported in the status and as Events of the Vault CR.
+	// See https://developer.hashicorp.com/vault/tutorials/raft/raft-lost-quorum for more details.
+	// default:
+	RaftRecovery *RaftRecovery `json:"raftRecovery,omitempty"`
+
+	// EncryptionKeyRotation rotates the barrier encryption key of Vault (sys/rotate) periodically.
+	// The key term and install time are reported in the status.
+	// default:
+	EncryptionKeyRotation *EncryptionKeyRotation `json:"encryptionKeyRotation,omitempty"`
+
+	// SealedPodHealing makes the operator heal the Vault Pods which stay sealed longer than a grace period,
+	// which the liveness probe doesn't catch. The operator re-unseals them with the stored unseal keys if it can,
+	// or deletes them with an exponential backoff. Every action is recorded as an Event of the Vault CR.
+	// default:
+	SealedPodHealing *SealedPodHealing `json:"sealedPodHealing,omitempty"`
+
+	// UpgradeStrategy orchestrates the upgrades of the Vault image: a raft snapshot is taken first, then the
+	// Vault Pods are deleted one at a time, and recreated with the new image (OnDelete StatefulSet update strategy).
+	// The standbys are updated first from the highest ordinal down, each updated Pod has to be unsealed and healthy
+	// before the next one is updated. The active node goes last, it is stepped down (sys/step-down) before it is
+	// updated, so only standbys are restarted. A failed health gate pauses the upgrade until the Pod becomes healthy.
+	// default:
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
+	// GracefulShutdown makes the active Vault node hand over the leadership (sys/step-down) in a preStop hook,
+	// before it is terminated by a rolling restart, a node drain or a scale down, and sets the termination
+	// grace period of the Vault Pods.
+	// default:
+	GracefulShutdown *GracefulShutdown `json:"gracefulShutdown,omitempty"`
+
+	// RolloutStrategy rolls out the changes of the Vault config to a few canary Pods first, by moving the
+	// StatefulSet partition step by step. The rest of the Pods are updated one by one once the canaries have
+	// stayed healthy for the pause duration. If an updated Pod doesn't become unsealed and healthy in time,
+	// the canary Pods are rolled back to the previous config, until the config changes again. The rolled back
+	// config holds back every other change of the Vault Pod template (reported in the status of the rollout),
+	// annotate the Vault with vault.banzaicloud.com/reset-rollout to roll it out again.
+	// Image upgrades are orchestrated by the upgradeStrategy instead, if set.
+	// default:
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// VersionPolicy relaxes the version guardrails: the operator compares the version reported by the running
+	// Vault Pods with the tag of the image, and refuses downgrades and upgrades skipping minor versions,
+	// by keeping the running image. Images without a version tag (for example :latest) are warned about.
+	// default:
+	VersionPolicy *VersionPolicy `json:"versionPolicy,omitempty"`
+
+	// MaintenanceWindows restrict the disruptive changes of the Vault Pod template (config, image, TLS certificate
+	// renewals, watched Secrets, ...) to the given time ranges. Outside of them the changes are staged and reported
+	// in the status. The vault.banzaicloud.io/apply-now annotation on the Vault CR applies them immediately.
+	// default: changes are applied immediately
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Paused stops the operator from changing the resources of this Vault, for example during an incident.
+	// The status is still reported.
+	// default: false
+	Paused bool `json:"paused,omitempty"`
+
+	// Hibernate scales the Vault StatefulSet and the configurer to zero, the PersistentVolumeClaims and the
+	// unseal keys are kept, so Vault resumes with its data once it is set to false again.
+	// default: false
+	Hibernate bool `json:"hibernate,omitempty"`
+
 	// ServicePorts is an extra map of ports that should be exposed by the Vault Service.
 	// default:
 	ServicePorts map[string]int32 `json:"servicePorts,omitempty"`
@@ -235,6 +313,12 @@ type VaultSpec struct {
 	// default:
 	NodeAffinity v1.NodeAffinity `json:"nodeAffinity,omitempty"`
 
+	// ZoneAware spreads the Vault Pods evenly across the availability zones (topology.kubernetes.io/zone) of the
+	// cluster, and with raft storage passes the zone of each Pod's Node to Vault as autopilot_redundancy_zone
+	// (Vault Enterprise), so a zone outage can't remove more voters than the quorum allows.
+	// default: false
+	ZoneAware bool `json:"zoneAware,omitempty"`
+
 	// NodeSelector is Kubernetees NodeSelector definition that should be applied to all Vault Pods.
 	// default:
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
@@ -290,6 +374,8 @@ type VaultSpec struct {
 	// ExistingTLSSecretName is name of the secret that contains a TLS server certificate and key and the corresponding CA certificate.
 	// Required secret format kubernetes.io/tls type secret keys + ca.crt key
 	// If it is set, generating certificate will be disabled
+	// The certificate has to be valid for the DNS name of every Vault Pod (<name>-<ordinal>.<namespace>),
+	// the operator verifies it when it talks to the Pods.
 	// default: ""
 	ExistingTLSSecretName string `json:"existingTlsSecretName,omitempty"`
 
@@ -629,9 +715,88 @@ func (spec *VaultSpec) ExternalConfigJSON() []byte {
 
 // IsAutoUnseal checks if auto-unseal is configured
 func (spec *VaultSpec) IsAutoUnseal() bool {
-	config := spec.GetVaultConfig()
-	_, ok := config["seal"]
-	return ok
+	return spec.GetSealType() != ShamirSealType
+}
+
+// ShamirSealType is the seal type of Vault without an enabled seal stanza
+const ShamirSealType = "shamir"
+
+// GetSealType returns the type of the enabled seal stanza, a seal with disabled = "true" is
+// only used to migrate away from it, see https://developer.hashicorp.com/vault/docs/concepts/seal#seal-migration
+func (spec *VaultSpec) GetSealType() string {
+	if spec.UnsealConfig.Transit != nil {
+		return "transit"
+	}
+
+	var seals []interface{}
+	switch seal := spec.GetVaultConfig()["seal"].(type) {
+	case map[string]interface{}:
+		seals = append(seals, seal)
+	case []interface{}:
+		seals = seal
+	}
+
+	for _, seal := range seals {
+		sealMap, ok := seal.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// The stanzas are checked in a stable order, a map has none
+		sealTypes := make([]string, 0, len(sealMap))
+		for sealType := range sealMap {
+			sealTypes = append(sealTypes, sealType)
+		}
+		sort.Strings(sealTypes)
+
+		for _, sealType := range sealTypes {
+			if !isSealDisabled(sealMap[sealType]) {
+				return sealType
+			}
+		}
+	}
+
+	return ShamirSealType
+}
+
+// isSealDisabled checks the disabled parameter of a seal stanza, which is a list of blocks in HCL converted to JSON
+func isSealDisabled(sealConfig interface{}) bool {
+	switch params := sealConfig.(type) {
+	case map[string]interface{}:
+		return cast.ToBool(params["disabled"])
+	case []interface{}:
+		for _, block := range params {
+			if isSealDisabled(block) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withTransitSeal sets the transit stanza of the seal config, the other stanzas are kept,
+// so a seal disabled for a seal migration stays in the config next to the transit seal
+func withTransitSeal(seal interface{}, transit map[string]interface{}) interface{} {
+	switch seal := seal.(type) {
+	case map[string]interface{}:
+		merged := make(map[string]interface{}, len(seal)+1)
+		for sealType, sealConfig := range seal {
+			merged[sealType] = sealConfig
+		}
+		merged["transit"] = transit
+		return merged
+	case []interface{}:
+		merged := make([]interface{}, 0, len(seal)+1)
+		for _, stanza := range seal {
+			if sealMap, ok := stanza.(map[string]interface{}); ok {
+				if _, ok := sealMap["transit"]; ok {
+					continue
+				}
+			}
+			merged = append(merged, stanza)
+		}
+		return append(merged, map[string]interface{}{"transit": transit})
+	}
+	return map[string]interface{}{"transit": transit}
 }
 
 // IsRaftStorage checks if raft storage is configured
@@ -649,22 +814,543 @@ func (spec *VaultSpec) IsRaftBootstrapFollower() bool {
 	return spec.RaftLeaderAddress != "" && spec.RaftLeaderAddress != "self"
 }
 
+// IsPerInstanceLoadBalancer checks if every Vault instance is exposed by its own LoadBalancer
+func (spec *VaultSpec) IsPerInstanceLoadBalancer() bool {
+	return spec.PerInstanceServiceType == string(v1.ServiceTypeLoadBalancer)
+}
+
+// IsRaftRetryJoin checks if raft retry_join stanzas should be generated for the Vault instances.
+// Multi-cluster followers join a remote leader, so they are not covered by the per-instance Services.
+func (spec *VaultSpec) IsRaftRetryJoin() bool {
+	return spec.RaftRetryJoinEnabled && spec.IsRaftStorage() && spec.Size > 1 && !spec.IsRaftBootstrapFollower()
+}
+
+// GetRaftPath returns the path of the raft storage directory
+func (spec *VaultSpec) GetRaftPath() string {
+	path := cast.ToString(spec.GetStorage()["path"])
+	if path == "" {
+		return "/vault/file"
+	}
+	return path
+}
+
+// GetRaftAddress returns the raft cluster address (host:port) of the given Vault Pod, based on the cluster_addr
+// configuration, where the ${.Env.POD_NAME} template is substituted with the Pod name
+func (vault *Vault) GetRaftAddress(podName string) string {
+	clusterAddr := cast.ToString(vault.Spec.GetVaultConfig()["cluster_addr"])
+	clusterAddr = strings.ReplaceAll(clusterAddr, "${.Env.POD_NAME}", podName)
+
+	if u, err := url.Parse(clusterAddr); err == nil && u.Host != "" && !strings.Contains(u.Host, "$") {
+		return u.Host
+	}
+
+	return fmt.Sprintf("%s.%s:8201", podName, vault.Namespace)
+}
+
+// RaftRecovery describes how a raft cluster with lost quorum should be recovered
+type RaftRecovery struct {
+	// Survivors is the list of Vault Pods with intact raft data, these will form the recovered cluster.
+	// default: the running Vault Pods
+	Survivors []string `json:"survivors,omitempty"`
+
+	// NodeIDs maps the Vault Pod names to raft node IDs, needed when the node_id in the raft storage stanza
+	// is not the Pod name.
+	// default: the Pod names
+	NodeIDs map[string]string `json:"nodeIDs,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time range in which disruptive changes are applied
+type MaintenanceWindow struct {
+	// Days the window opens on, as Mon, Tue, Wed, Thu, Fri, Sat or Sun.
+	// default: every day
+	Days []string `json:"days,omitempty"`
+
+	// Start of the window, as HH:MM.
+	Start string `json:"start"`
+
+	// End of the window, as HH:MM. If it is not after the start, the window ends on the next day.
+	End string `json:"end"`
+
+	// TimeZone of the start and end, as an IANA time zone name, for example Europe/Budapest.
+	// default: UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// VersionPolicy holds the exceptions from the Vault version guardrails
+type VersionPolicy struct {
+	// AllowDowngrade allows an image with a lower version than the running one.
+	AllowDowngrade bool `json:"allowDowngrade,omitempty"`
+
+	// AllowSkipVersions allows upgrades to a new major version, or over more than one minor version.
+	AllowSkipVersions bool `json:"allowSkipVersions,omitempty"`
+}
+
+// RolloutStrategy holds the parameters of the canary rollout of Vault config changes
+type RolloutStrategy struct {
+	// Canaries is the number of Vault Pods (with the highest ordinals) which get the new config first.
+	// default: 1
+	Canaries *int32 `json:"canaries,omitempty"`
+
+	// Pause is how long the canaries have to run before the rest of the Vault Pods are updated.
+	// default: 1m
+	Pause *metav1.Duration `json:"pause,omitempty"`
+
+	// HealthGateTimeout is how long an updated Vault Pod may take to become unsealed and healthy,
+	// the rollout is rolled back after it.
+	// default: 5m
+	HealthGateTimeout *metav1.Duration `json:"healthGateTimeout,omitempty"`
+}
+
+// GetCanaries returns the number of canary Vault Pods, at most the size of the cluster
+func (strategy *RolloutStrategy) GetCanaries(size int32) int32 {
+	canaries := int32(1)
+	if strategy.Canaries != nil && *strategy.Canaries > 0 {
+		canaries = *strategy.Canaries
+	}
+	if canaries > size {
+		return size
+	}
+	return canaries
+}
+
+// GetPause returns how long the canaries have to run before the rollout continues
+func (strategy *RolloutStrategy) GetPause() time.Duration {
+	if strategy.Pause != nil {
+		return strategy.Pause.Duration
+	}
+	return time.Minute
+}
+
+// GetHealthGateTimeout returns how long an updated Vault Pod may take to become healthy
+func (strategy *RolloutStrategy) GetHealthGateTimeout() time.Duration {
+	if strategy.HealthGateTimeout != nil && strategy.HealthGateTimeout.Duration > 0 {
+		return strategy.HealthGateTimeout.Duration
+	}
+	return 5 * time.Minute
+}
+
+// GracefulShutdown holds the parameters of the graceful shutdown of the Vault Pods
+type GracefulShutdown struct {
+	// StepDownTokenSecretRef selects a Vault token allowed to update sys/step-down, in the namespace of the Vault.
+	// It is mounted into the Vault Pods, so it should not be the root token. No step-down happens without it.
+	// default:
+	StepDownTokenSecretRef *v1.SecretKeySelector `json:"stepDownTokenSecretRef,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time the Vault Pods get to step down and shut down before they are killed.
+	// default: 30
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// UpgradeStrategy holds the parameters of the orchestrated Vault upgrades
+type UpgradeStrategy struct {
+	// Snapshot saves a raft snapshot before the upgrade starts, only supported with raft storage.
+	// default:
+	Snapshot *UpgradeSnapshot `json:"snapshot,omitempty"`
+
+	// HealthTimeout is how long an updated Vault Pod may take to become unsealed and healthy,
+	// the upgrade is paused after it.
+	// default: 10m
+	HealthTimeout *metav1.Duration `json:"healthTimeout,omitempty"`
+
+	// TokenSecretRef selects a Vault token allowed to step down the active node and to save raft snapshots.
+	// default: the root token stored in the Kubernetes Secret of the unseal keys, required if revokeRootToken is enabled
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// UpgradeSnapshot describes where the pre-upgrade raft snapshots are saved
+type UpgradeSnapshot struct {
+	// PersistentVolumeClaimName is the claim the snapshots are saved to, by a Job, as <vault>-<time>.snap.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+}
+
+// GetHealthTimeout returns how long an updated Vault Pod may take to become healthy
+func (strategy *UpgradeStrategy) GetHealthTimeout() time.Duration {
+	if strategy.HealthTimeout != nil && strategy.HealthTimeout.Duration > 0 {
+		return strategy.HealthTimeout.Duration
+	}
+	return 10 * time.Minute
+}
+
+// SealedPodHealing holds the parameters of healing the Vault Pods which stay sealed
+type SealedPodHealing struct {
+	// GracePeriod is how long a Vault Pod may stay sealed before the operator intervenes.
+	// default: 5m
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// RestartBackoff is the time to wait after deleting a Vault Pod before deleting it again,
+	// it is doubled after every consecutive deletion of the same Pod, up to 1h.
+	// default: 1m
+	RestartBackoff *metav1.Duration `json:"restartBackoff,omitempty"`
+}
+
+// GetGracePeriod returns how long a Vault Pod may stay sealed before it is healed
+func (healing *SealedPodHealing) GetGracePeriod() time.Duration {
+	if healing.GracePeriod != nil && healing.GracePeriod.Duration > 0 {
+		return healing.GracePeriod.Duration
+	}
+	return 5 * time.Minute
+}
+
+// GetRestartBackoff returns the time to wait before deleting a sealed Vault Pod again after the given number of deletions
+func (healing *SealedPodHealing) GetRestartBackoff(restarts int) time.Duration {
+	backoff := time.Minute
+	if healing.RestartBackoff != nil && healing.RestartBackoff.Duration > 0 {
+		backoff = healing.RestartBackoff.Duration
+	}
+	for i := 1; i < restarts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	if backoff > time.Hour {
+		return time.Hour
+	}
+	return backoff
+}
+
+// EncryptionKeyRotation holds the schedule of the barrier encryption key rotation
+type EncryptionKeyRotation struct {
+	// Period is the maximum age of the encryption key, for example 2160h (90 days).
+	Period metav1.Duration `json:"period"`
+
+	// TokenSecretRef selects a Vault token allowed to update sys/rotate.
+	// default: the root token stored in the Kubernetes Secret of the unseal keys, required if revokeRootToken is enabled
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// GetNodeID returns the raft node ID of the given Vault Pod
+func (recovery *RaftRecovery) GetNodeID(podName string) string {
+	if nodeID, ok := recovery.NodeIDs[podName]; ok {
+		return nodeID
+	}
+	return podName
+}
+
+// RaftRecoveryPhase is the phase of a raft cluster recovery
+type RaftRecoveryPhase string
+
+const (
+	// RaftRecoveryPeersWritten means that the peers.json has been prepared for the survivors
+	RaftRecoveryPeersWritten RaftRecoveryPhase = "PeersWritten"
+	// RaftRecoveryRestarting means that the survivors are restarted to pick up the peers.json
+	RaftRecoveryRestarting RaftRecoveryPhase = "Restarting"
+	// RaftRecoveryCompleted means that the recovered cluster has an active leader
+	RaftRecoveryCompleted RaftRecoveryPhase = "Completed"
+	// RaftRecoveryFailed means that the recovery can't proceed, remove spec.raftRecovery to abort it
+	RaftRecoveryFailed RaftRecoveryPhase = "Failed"
+)
+
+// RaftRecoveryStatus is the observed state of a raft cluster recovery
+type RaftRecoveryStatus struct {
+	Phase              RaftRecoveryPhase `json:"phase"`
+	Survivors          []string          `json:"survivors,omitempty"`
+	Message            string            `json:"message,omitempty"`
+	LastTransitionTime metav1.Time       `json:"lastTransitionTime,omitempty"`
+}
+
+// UnsealKeyMirrorState is the consistency state of an unseal key mirror
+type UnsealKeyMirrorState string
+
+const (
+	// UnsealKeyMirrorInSync means the mirror holds the same unseal keys as the primary backend
+	UnsealKeyMirrorInSync UnsealKeyMirrorState = "InSync"
+	// UnsealKeyMirrorPending means the primary backend holds no unseal keys yet
+	UnsealKeyMirrorPending UnsealKeyMirrorState = "Pending"
+	// UnsealKeyMirrorDiverged means the mirror has been changed by someone else than the operator
+	UnsealKeyMirrorDiverged UnsealKeyMirrorState = "Diverged"
+	// UnsealKeyMirrorFailed means the mirror can't be checked or written
+	UnsealKeyMirrorFailed UnsealKeyMirrorState = "Failed"
+)
+
+// UnsealKeyMirrorStatus is the observed state of an unseal key mirror
+type UnsealKeyMirrorStatus struct {
+	SecretNamespace    string               `json:"secretNamespace"`
+	SecretName         string               `json:"secretName"`
+	State              UnsealKeyMirrorState `json:"state"`
+	Message            string               `json:"message,omitempty"`
+	LastTransitionTime metav1.Time          `json:"lastTransitionTime,omitempty"`
+}
+
+// UnsealEscrowStatus is the observed state of the unseal key escrow
+type UnsealEscrowStatus struct {
+	Initialized     bool     `json:"initialized"`
+	KeyHolders      []string `json:"keyHolders,omitempty"`
+	Threshold       int      `json:"threshold,omitempty"`
+	SubmittedShares int      `json:"submittedShares,omitempty"`
+	// SubmissionPublicKey is the PEM encoded RSA public key the submitted unseal shares are encrypted to
+	SubmissionPublicKey string   `json:"submissionPublicKey,omitempty"`
+	SealedPods          []string `json:"sealedPods,omitempty"`
+	Message             string   `json:"message,omitempty"`
+}
+
+// OperatorUnsealStatus is the state of the initialization and unsealing done by the operator
+type OperatorUnsealStatus struct {
+	Initialized bool     `json:"initialized"`
+	SealedPods  []string `json:"sealedPods,omitempty"`
+	Message     string   `json:"message,omitempty"`
+}
+
+// VolumeExpansionPhase is the phase of a PersistentVolumeClaim expansion
+type VolumeExpansionPhase string
+
+const (
+	// VolumeExpansionExpanding means that the claims have been patched, the expansion waits for their capacity
+	VolumeExpansionExpanding VolumeExpansionPhase = "Expanding"
+	// VolumeExpansionCompleted means that the capacity of every claim has reached the requested size
+	VolumeExpansionCompleted VolumeExpansionPhase = "Completed"
+	// VolumeExpansionFailed means that the claims can't be expanded, the previous templates are kept
+	VolumeExpansionFailed VolumeExpansionPhase = "Failed"
+)
+
+// VolumeExpansionStatus is the state of the expansion of the Vault PersistentVolumeClaims
+type VolumeExpansionStatus struct {
+	Phase              VolumeExpansionPhase   `json:"phase,omitempty"`
+	Claims             []ClaimExpansionStatus `json:"claims,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+}
+
+// ClaimExpansionStatus is the state of the expansion of a PersistentVolumeClaim
+type ClaimExpansionStatus struct {
+	Name      string `json:"name"`
+	Requested string `json:"requested"`
+	Capacity  string `json:"capacity,omitempty"`
+}
+
+// MaintenanceStatus is the state of the changes staged until the next maintenance window
+type MaintenanceStatus struct {
+	// PendingChanges is true if a change of the Vault Pod template waits for a maintenance window
+	PendingChanges bool         `json:"pendingChanges"`
+	PendingSince   *metav1.Time `json:"pendingSince,omitempty"`
+	NextWindow     *metav1.Time `json:"nextWindow,omitempty"`
+	Message        string       `json:"message,omitempty"`
+}
+
+// VersionStatus is the state of the Vault version
+type VersionStatus struct {
+	// Running is the version reported by the active Vault Pod
+	Running string `json:"running,omitempty"`
+	// Message explains why the requested image is refused or warned about
+	Message string `json:"message,omitempty"`
+	// History lists the versions Vault has run, the latest last
+	History []VersionHistoryEntry `json:"history,omitempty"`
+}
+
+// VersionHistoryEntry records a Vault version and when it was first seen running
+type VersionHistoryEntry struct {
+	Version string      `json:"version"`
+	Image   string      `json:"image,omitempty"`
+	Time    metav1.Time `json:"time"`
+}
+
+// RolloutPhase is the phase of a canary rollout
+type RolloutPhase string
+
+const (
+	// RolloutCanary means the canary Pods are updated, and observed for the pause duration
+	RolloutCanary RolloutPhase = "Canary"
+	// RolloutProgressing means the rest of the Pods are updated one by one
+	RolloutProgressing RolloutPhase = "Progressing"
+	// RolloutCompleted means every Vault Pod runs the new config
+	RolloutCompleted RolloutPhase = "Completed"
+	// RolloutRollingBack means the updated Pods are being restarted with the previous config
+	RolloutRollingBack RolloutPhase = "RollingBack"
+	// RolloutRolledBack means the updated Pods run the previous config again, until the config changes or the rollout is reset
+	RolloutRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutStatus is the state of a canary rollout
+type RolloutStatus struct {
+	Phase RolloutPhase `json:"phase"`
+	// ConfigSum is the checksum of the Vault config being rolled out
+	ConfigSum string `json:"configSum"`
+	// Partition is the partition of the StatefulSet, the Pods with a lower ordinal are not updated yet
+	Partition          int32        `json:"partition"`
+	StepStartTime      *metav1.Time `json:"stepStartTime,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime metav1.Time  `json:"lastTransitionTime,omitempty"`
+}
+
+// UpgradePhase is the phase of an orchestrated upgrade
+type UpgradePhase string
+
+const (
+	// UpgradeSnapshotting means the pre-upgrade raft snapshot is being saved
+	UpgradeSnapshotting UpgradePhase = "Snapshotting"
+	// UpgradeRollingOut means the Vault Pods are being updated one by one
+	UpgradeRollingOut UpgradePhase = "RollingOut"
+	// UpgradePaused means a health gate has failed, the upgrade continues once it passes
+	UpgradePaused UpgradePhase = "Paused"
+	// UpgradeCompleted means every Vault Pod runs the new image
+	UpgradeCompleted UpgradePhase = "Completed"
+)
+
+// UpgradeStatus is the state of an orchestrated upgrade
+type UpgradeStatus struct {
+	Phase     UpgradePhase `json:"phase"`
+	FromImage string       `json:"fromImage,omitempty"`
+	ToImage   string       `json:"toImage,omitempty"`
+	// SnapshotJob is the name of the Job saving the pre-upgrade raft snapshot
+	SnapshotJob string `json:"snapshotJob,omitempty"`
+	// Pod is the Vault Pod updated by the current step
+	Pod                string       `json:"pod,omitempty"`
+	StepStartTime      *metav1.Time `json:"stepStartTime,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime metav1.Time  `json:"lastTransitionTime,omitempty"`
+}
+
+// SealedPodStatus tracks a sealed Vault Pod and the healing actions taken
+type SealedPodStatus struct {
+	Name        string      `json:"name"`
+	SealedSince metav1.Time `json:"sealedSince"`
+	// Restarts is the number of times the Pod has been deleted since it is sealed
+	Restarts        int          `json:"restarts,omitempty"`
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+	Message         string       `json:"message,omitempty"`
+}
+
+// RekeyPhase is the phase of a rekey ceremony
+type RekeyPhase string
+
+const (
+	// RekeyStarted means that the old unseal keys are being submitted to Vault
+	RekeyStarted RekeyPhase = "Started"
+	// RekeyVerifying means that the new unseal keys are staged and being verified
+	RekeyVerifying RekeyPhase = "Verifying"
+	// RekeyCompleted means that the new unseal keys are active and stored in the backend
+	RekeyCompleted RekeyPhase = "Completed"
+	// RekeyFailed means that the rekey can't proceed, see the message for the details. A failed ceremony is
+	// retried with an exponential backoff, up to an hour
+	RekeyFailed RekeyPhase = "Failed"
+)
+
+// RekeyStatus is the observed state of a rekey ceremony
+type RekeyStatus struct {
+	Phase           RekeyPhase `json:"phase"`
+	Nonce           string     `json:"nonce,omitempty"`
+	Progress        int        `json:"progress,omitempty"`
+	Required        int        `json:"required,omitempty"`
+	SecretShares    int        `json:"secretShares"`
+	SecretThreshold int        `json:"secretThreshold"`
+	Message         string     `json:"message,omitempty"`
+	// Attempts is the number of failed rekey ceremonies in a row, they are retried with an exponential backoff
+	Attempts           int         `json:"attempts,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// EncryptionKeyStatus is the observed state of the barrier encryption key
+type EncryptionKeyStatus struct {
+	Term             int         `json:"term,omitempty"`
+	InstallTime      metav1.Time `json:"installTime,omitempty"`
+	LastRotationTime metav1.Time `json:"lastRotationTime,omitempty"`
+	NextRotationTime metav1.Time `json:"nextRotationTime,omitempty"`
+	Message          string      `json:"message,omitempty"`
+}
+
+// RootTokenStatus is the observed state of the root token revocation
+type RootTokenStatus struct {
+	Revoked        bool        `json:"revoked"`
+	RevocationTime metav1.Time `json:"revocationTime,omitempty"`
+	Message        string      `json:"message,omitempty"`
+}
+
+// SealMigrationPhase is the phase of a seal migration
+type SealMigrationPhase string
+
+const (
+	// SealMigrationMigrating means that the restarted Vault Pods are unsealed with -migrate
+	SealMigrationMigrating SealMigrationPhase = "Migrating"
+	// SealMigrationCompleted means that every Vault Pod runs with the new seal
+	SealMigrationCompleted SealMigrationPhase = "Completed"
+	// SealMigrationFailed means that the seal change can't be migrated, see the message for the details
+	SealMigrationFailed SealMigrationPhase = "Failed"
+)
+
+// SealMigrationStatus is the observed state of a seal migration
+type SealMigrationStatus struct {
+	Phase              SealMigrationPhase `json:"phase"`
+	From               string             `json:"from"`
+	To                 string             `json:"to"`
+	MigratedPods       []string           `json:"migratedPods,omitempty"`
+	Message            string             `json:"message,omitempty"`
+	LastTransitionTime metav1.Time        `json:"lastTransitionTime,omitempty"`
+}
+
+// TransitUnsealStatus is the observed state of the transit auto-unseal token
+type TransitUnsealStatus struct {
+	TokenAccessor  string      `json:"tokenAccessor,omitempty"`
+	TokenIssueTime metav1.Time `json:"tokenIssueTime,omitempty"`
+	Message        string      `json:"message,omitempty"`
+}
+
 // VaultStatus defines the observed state of Vault
 type VaultStatus struct {
 	// Important: Run "make generate-code" to regenerate code after modifying this file
-	Nodes      []string                `json:"nodes"`
-	Leader     string                  `json:"leader"`
-	Conditions []v1.ComponentCondition `json:"conditions,omitempty"`
+	Nodes        []string                `json:"nodes"`
+	Leader       string                  `json:"leader"`
+	Conditions   []v1.ComponentCondition `json:"conditions,omitempty"`
+	RaftRecovery *RaftRecoveryStatus     `json:"raftRecovery,omitempty"`
+	// DeprecatedFields lists the plain text sensitive fields in use, a Warning Event is emitted when one is added
+	DeprecatedFields []string `json:"deprecatedFields,omitempty"`
+	// UnsealKeyMirrors reports the consistency of each unseal key mirror with the primary backend
+	UnsealKeyMirrors []UnsealKeyMirrorStatus `json:"unsealKeyMirrors,omitempty"`
+	Escrow           *UnsealEscrowStatus     `json:"escrow,omitempty"`
+	// Rekey reports the last rekey ceremony run after a change of the secret shares or threshold
+	Rekey *RekeyStatus `json:"rekey,omitempty"`
+	// EncryptionKey reports the barrier encryption key, if its rotation is scheduled
+	EncryptionKey *EncryptionKeyStatus `json:"encryptionKey,omitempty"`
+	// RootToken reports the revocation of the root token, if enabled
+	RootToken *RootTokenStatus `json:"rootToken,omitempty"`
+	// SealMigration reports the last migration between Shamir and auto-unseal
+	SealMigration *SealMigrationStatus `json:"sealMigration,omitempty"`
+	// TransitUnseal reports the token issued by the unsealing Vault for transit auto-unseal
+	TransitUnseal *TransitUnsealStatus `json:"transitUnseal,omitempty"`
+	// Unseal reports the initialization and unsealing of the Vault Pods, if they are unsealed by the operator
+	Unseal *OperatorUnsealStatus `json:"unseal,omitempty"`
+	// SealedPods reports the Vault Pods which are sealed, if sealed Pod healing is enabled
+	SealedPods []SealedPodStatus `json:"sealedPods,omitempty"`
+	// Upgrade reports the last orchestrated upgrade of the Vault image
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+	// Rollout reports the last canary rollout of a Vault config change
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Version reports the running Vault version, the refused version changes, and the version history
+	Version *VersionStatus `json:"version,omitempty"`
+	// Maintenance reports the changes staged until the next maintenance window
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+	// VolumeExpansion reports the expansion of the PersistentVolumeClaims after a volumeClaimTemplates size change
+	VolumeExpansion *VolumeExpansionStatus `json:"volumeExpansion,omitempty"`
 }
 
 // UnsealOptions represents the common options to all unsealing backends
 type UnsealOptions struct {
 	PreFlightChecks *bool `json:"preFlightChecks,omitempty"`
 	StoreRootToken  *bool `json:"storeRootToken,omitempty"`
+	// RevokeRootToken revokes the stored root token once Vault is initialized, and removes it from the Kubernetes
+	// Secret of the unseal keys. A root token can be issued later by a VaultRootTokenRequest. If externalConfig is set,
+	// the configurer authenticates with the stored root token, so it is only revoked once the configurer has annotated
+	// the Vault with vault.banzaicloud.com/external-config-applied set to the sha256 sum of externalConfig, after it
+	// has applied it. The configurer can't apply later changes of externalConfig then. This is false by default.
+	RevokeRootToken *bool `json:"revokeRootToken,omitempty"`
+	// SecretThreshold and SecretShares are used at init, changing them later rekeys Vault with the stored
+	// unseal keys. Rekeying is only supported if the unseal keys are stored in a Kubernetes Secret.
 	SecretThreshold *uint `json:"secretThreshold,omitempty"`
 	SecretShares    *uint `json:"secretShares,omitempty"`
 }
 
+// GetSecretShares returns the number of unseal key shares
+func (options UnsealOptions) GetSecretShares() int {
+	if options.SecretShares != nil {
+		return int(*options.SecretShares)
+	}
+	return 5
+}
+
+// GetSecretThreshold returns the number of unseal key shares required to unseal Vault
+func (options UnsealOptions) GetSecretThreshold() int {
+	if options.SecretThreshold != nil {
+		return int(*options.SecretThreshold)
+	}
+	return 3
+}
+
 // UnsealConfig represents the UnsealConfig field of a VaultSpec Kubernetes object
 type UnsealConfig struct {
 	Options    UnsealOptions          `json:"options,omitempty"`
@@ -676,6 +1362,119 @@ type UnsealConfig struct {
 	OCI        *OCIUnsealConfig       `json:"oci,omitempty"`
 	Vault      *VaultUnsealConfig     `json:"vault,omitempty"`
 	HSM        *HSMUnsealConfig       `json:"hsm,omitempty"`
+
+	// Transit auto-unseals Vault with the transit secrets engine of another Vault CR, the operator sets up
+	// the engine, the key and a narrowly scoped token on that Vault, and injects the seal stanza.
+	// The recovery keys and root token are still stored by the backend configured above.
+	Transit *TransitUnsealConfig `json:"transit,omitempty"`
+
+	// Mirrors lists Kubernetes Secrets (for example in a break-glass namespace) where the operator keeps a copy
+	// of the unseal keys and root token, the copies are checked for consistency and divergence is reported in status.
+	// Supported with a primary backend storing them in a Kubernetes Secret (kubernetes or hsm-k8s mode), or in AWS
+	// (KMS encrypted S3 objects, read with the AWS credentials of the operator, which needs kms:Decrypt and s3:GetObject).
+	// The other primary backends are reported as Failed.
+	Mirrors []KubernetesUnsealConfig `json:"mirrors,omitempty"`
+
+	// Escrow initializes Vault with one unseal key share per key holder, each encrypted with the PGP key of its holder,
+	// so no single system holds the shares in plaintext. The operator initializes Vault and joins the raft cluster
+	// instead of bank-vaults, no unseal sidecar runs, and Vault has to be unsealed by a threshold of key holders.
+	Escrow *UnsealEscrow `json:"escrow,omitempty"`
+
+	// OperatorUnseal makes the operator initialize, join (with raft storage) and unseal the Vault Pods through
+	// the Vault API, instead of running a bank-vaults unseal sidecar in every Pod. Only supported if the keys are
+	// stored in a Kubernetes Secret (kubernetes mode, without hsm and escrow), other backends keep the sidecar.
+	OperatorUnseal bool `json:"operatorUnseal,omitempty"`
+}
+
+// IsOperatorUnseal checks if the Vault Pods are initialized and unsealed by the operator instead of the sidecar
+func (usc *UnsealConfig) IsOperatorUnseal(vault *Vault) bool {
+	if !usc.OperatorUnseal || usc.HSM != nil || usc.Escrow != nil {
+		return false
+	}
+	_, _, ok := usc.GetKubernetesSecret(vault)
+	return ok
+}
+
+// UnsealEscrow holds the parameters of PGP encrypted unseal key escrow
+type UnsealEscrow struct {
+	// KeyHolders receive one unseal key share each, stored in the <vault>-unseal-share-<name> Secret.
+	// The threshold of the shares comes from options.secretThreshold, options.secretShares is ignored.
+	KeyHolders []UnsealEscrowKeyHolder `json:"keyHolders"`
+
+	// RootTokenPGPKey encrypts the initial root token, which is stored in the <vault>-root-token Secret.
+	// If not set, the initial root token is revoked right after initialization.
+	RootTokenPGPKey *PGPKey `json:"rootTokenPGPKey,omitempty"`
+
+	// ShareSubmission enables unsealing through the operator: key holders create Secrets in the namespace of
+	// the Vault labeled with vault.banzaicloud.com/unseal-share-submission=<vault name>, holding their decrypted
+	// share under the "share" key, encrypted with RSA-OAEP (SHA-256) to the public key in status.escrow.submissionPublicKey.
+	// The private key only lives in the memory of the operator, the shares have to be submitted again after it restarts.
+	// Once a threshold of shares is submitted the operator unseals the sealed Vault Pods and deletes the submissions.
+	// Without it, the Vault Pods have to be unsealed manually.
+	// default: false
+	ShareSubmission bool `json:"shareSubmission,omitempty"`
+}
+
+// UnsealEscrowKeyHolder is the holder of a single unseal key share
+type UnsealEscrowKeyHolder struct {
+	Name   string `json:"name"`
+	PGPKey `json:",inline"`
+}
+
+// PGPKey is a PGP public key in the format Vault expects: base64 encoded binary key or keybase:<username>
+type PGPKey struct {
+	PublicKey          string                `json:"publicKey,omitempty"`
+	PublicKeySecretRef *v1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+}
+
+// GetThreshold returns the number of key holders needed to unseal Vault
+func (escrow *UnsealEscrow) GetThreshold(options UnsealOptions) int {
+	if options.SecretThreshold != nil {
+		return int(*options.SecretThreshold)
+	}
+	return min(3, len(escrow.KeyHolders))
+}
+
+// RevokesRootToken returns true if the stored root token is revoked once Vault is initialized
+func (usc *UnsealConfig) RevokesRootToken() bool {
+	return usc.Options.RevokeRootToken != nil && *usc.Options.RevokeRootToken
+}
+
+// ToEnv returns the sensitive parameters of the UnsealConfig selected from Secrets as environment variables for bank-vaults,
+// so they don't show up on the command line
+func (usc *UnsealConfig) ToEnv() []v1.EnvVar {
+	var envs []v1.EnvVar
+
+	if usc.Vault != nil && usc.Vault.TokenSecretRef != nil {
+		envs = append(envs, v1.EnvVar{
+			Name:      "BANK_VAULTS_VAULT_TOKEN",
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: usc.Vault.TokenSecretRef},
+		})
+	}
+
+	if usc.HSM != nil && usc.HSM.PinSecretRef != nil {
+		envs = append(envs, v1.EnvVar{
+			Name:      "BANK_VAULTS_HSM_PIN",
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: usc.HSM.PinSecretRef},
+		})
+	}
+
+	return envs
+}
+
+// DeprecatedFields returns the plain text sensitive fields of the UnsealConfig which are in use
+func (usc *UnsealConfig) DeprecatedFields() []string {
+	var fields []string
+
+	if usc.Vault != nil && usc.Vault.Token != "" {
+		fields = append(fields, "unsealConfig.vault.token")
+	}
+
+	if usc.HSM != nil && usc.HSM.Pin != "" {
+		fields = append(fields, "unsealConfig.hsm.pin")
+	}
+
+	return fields
 }
 
 // ToArgs returns the UnsealConfig as and argument array for bank-vaults
@@ -787,17 +1586,20 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 			usc.Vault.UnsealKeysPath,
 		)
 
-		if usc.Vault.Token != "" {
+		switch {
+		case usc.Vault.TokenSecretRef != nil:
+			// A token from a Secret is passed as an environment variable, see ToEnv
+		case usc.Vault.Token != "":
 			args = append(args,
 				"--vault-token",
 				usc.Vault.Token,
 			)
-		} else if usc.Vault.TokenPath != "" {
+		case usc.Vault.TokenPath != "":
 			args = append(args,
 				"--vault-token-path",
 				usc.Vault.TokenPath,
 			)
-		} else if usc.Vault.Role != "" {
+		case usc.Vault.Role != "":
 			args = append(args,
 				"--vault-role",
 				usc.Vault.Role,
@@ -822,7 +1624,8 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 			usc.HSM.KeyLabel,
 		)
 
-		if usc.HSM.Pin != "" {
+		// A PIN from a Secret is passed as an environment variable, see ToEnv
+		if usc.HSM.Pin != "" && usc.HSM.PinSecretRef == nil {
 			args = append(args,
 				"--hsm-pin",
 				usc.HSM.Pin,
@@ -854,15 +1657,7 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 			)
 		}
 	} else {
-		secretNamespace := vault.Namespace
-		if usc.Kubernetes.SecretNamespace != "" {
-			secretNamespace = usc.Kubernetes.SecretNamespace
-		}
-
-		secretName := vault.Name + "-unseal-keys"
-		if usc.Kubernetes.SecretName != "" {
-			secretName = usc.Kubernetes.SecretName
-		}
+		secretNamespace, secretName, _ := usc.GetKubernetesSecret(vault)
 
 		var secretLabels []string
 		for k, v := range vault.LabelsForVault() {
@@ -886,6 +1681,30 @@ func (usc *UnsealConfig) ToArgs(vault *Vault) []string {
 	return args
 }
 
+// GetKubernetesSecret returns the namespace and name of the Secret where bank-vaults stores the unseal keys,
+// the last return value is false if the unseal keys are not stored in a Kubernetes Secret
+func (usc *UnsealConfig) GetKubernetesSecret(vault *Vault) (string, string, bool) {
+	secretNamespace := vault.Namespace
+	if usc.Kubernetes.SecretNamespace != "" {
+		secretNamespace = usc.Kubernetes.SecretNamespace
+	}
+
+	secretName := vault.Name + "-unseal-keys"
+	if usc.Kubernetes.SecretName != "" {
+		secretName = usc.Kubernetes.SecretName
+	}
+
+	if usc.HSM != nil {
+		// hsm-k8s mode
+		return usc.Kubernetes.SecretNamespace, usc.Kubernetes.SecretName,
+			usc.Kubernetes.SecretNamespace != "" && usc.Kubernetes.SecretName != ""
+	}
+
+	isKubernetes := usc.Google == nil && usc.Alibaba == nil && usc.Azure == nil && usc.AWS == nil && usc.OCI == nil && usc.Vault == nil
+
+	return secretNamespace, secretName, isKubernetes
+}
+
 // HSMDaemonNeeded returns if the unsealing mechanism needs a HSM Daemon present
 func (usc *UnsealConfig) HSMDaemonNeeded() bool {
 	return usc.HSM != nil && usc.HSM.Daemon
@@ -949,7 +1768,77 @@ type VaultUnsealConfig struct {
 	Role           string `json:"role,omitempty"`
 	AuthPath       string `json:"authPath,omitempty"`
 	TokenPath      string `json:"tokenPath,omitempty"`
-	Token          string `json:"token,omitempty"`
+	// Deprecated: use TokenSecretRef instead, the token is visible in the Pod spec and in the process list.
+	Token string `json:"token,omitempty"`
+	// TokenSecretRef selects the Vault token from a Secret, it is passed to Bank-Vaults as an environment variable.
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// TransitUnsealConfig holds the parameters for transit auto-unseal with another Vault CR
+type TransitUnsealConfig struct {
+	// VaultRef selects the Vault CR which provides the transit secrets engine.
+	VaultRef TransitVaultRef `json:"vaultRef"`
+
+	// MountPath is the path of the transit secrets engine on the unsealing Vault.
+	// default: transit
+	MountPath string `json:"mountPath,omitempty"`
+
+	// KeyName is the name of the transit key.
+	// default: <namespace>-<name> of this Vault CR
+	KeyName string `json:"keyName,omitempty"`
+
+	// TokenPeriod is the period of the token used by Vault to call the transit engine, Vault renews it itself.
+	// default: 24h
+	TokenPeriod *metav1.Duration `json:"tokenPeriod,omitempty"`
+
+	// TokenRotationPeriod is the age after which the token is replaced by a new one, this restarts the Vault Pods.
+	// default: 720h
+	TokenRotationPeriod *metav1.Duration `json:"tokenRotationPeriod,omitempty"`
+
+	// TokenSecretRef selects a token of the unsealing Vault allowed to manage mounts, policies and tokens,
+	// from a Secret in the namespace of this Vault CR.
+	// default: the root token stored in the Kubernetes Secret of the unseal keys of the unsealing Vault,
+	// required if revokeRootToken is enabled on the unsealing Vault
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// TransitVaultRef references a Vault CR
+type TransitVaultRef struct {
+	Name string `json:"name"`
+	// default: the namespace of this Vault CR
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetMountPath returns the path of the transit secrets engine
+func (transit *TransitUnsealConfig) GetMountPath() string {
+	if transit.MountPath != "" {
+		return strings.Trim(transit.MountPath, "/")
+	}
+	return "transit"
+}
+
+// GetKeyName returns the name of the transit key
+func (transit *TransitUnsealConfig) GetKeyName(vault *Vault) string {
+	if transit.KeyName != "" {
+		return transit.KeyName
+	}
+	return vault.Namespace + "-" + vault.Name
+}
+
+// GetTokenPeriod returns the period of the transit token
+func (transit *TransitUnsealConfig) GetTokenPeriod() time.Duration {
+	if transit.TokenPeriod != nil && transit.TokenPeriod.Duration > 0 {
+		return transit.TokenPeriod.Duration
+	}
+	return 24 * time.Hour
+}
+
+// GetTokenRotationPeriod returns the age after which the transit token is replaced
+func (transit *TransitUnsealConfig) GetTokenRotationPeriod() time.Duration {
+	if transit.TokenRotationPeriod != nil && transit.TokenRotationPeriod.Duration > 0 {
+		return transit.TokenRotationPeriod.Duration
+	}
+	return 30 * 24 * time.Hour
 }
 
 // HSMUnsealConfig holds the parameters for remote HSM based unsealing
@@ -958,9 +1847,12 @@ type HSMUnsealConfig struct {
 	ModulePath string `json:"modulePath"`
 	SlotID     uint   `json:"slotId,omitempty"`
 	TokenLabel string `json:"tokenLabel,omitempty"`
+	// Deprecated: use PinSecretRef instead, the PIN is visible in the Pod spec and in the process list.
 	// +optional
-	Pin      string `json:"pin"`
-	KeyLabel string `json:"keyLabel"`
+	Pin string `json:"pin"`
+	// PinSecretRef selects the HSM user PIN from a Secret, it is passed to Bank-Vaults as an environment variable.
+	PinSecretRef *v1.SecretKeySelector `json:"pinSecretRef,omitempty"`
+	KeyLabel     string                `json:"keyLabel"`
 }
 
 // CredentialsConfig configuration for a credentials file provided as a secret
@@ -1021,6 +1913,56 @@ func (vault *Vault) ConfigJSON() ([]byte, error) {
 		}
 	}
 
+	// The advertised address is resolved by the config templating init container of each Vault Pod
+	if vault.Spec.IsPerInstanceLoadBalancer() {
+		if _, ok := config["api_addr"]; !ok {
+			config["api_addr"] = vault.Spec.GetAPIScheme() + "://${.Env.VAULT_ADVERTISE_ADDRESS}:8200"
+		}
+		if _, ok := config["cluster_addr"]; !ok {
+			config["cluster_addr"] = "https://${.Env.VAULT_ADVERTISE_ADDRESS}:8201"
+		}
+	}
+
+	// The zone is resolved by the config templating init container of each Vault Pod
+	if vault.Spec.ZoneAware && vault.Spec.IsRaftStorage() {
+		redundancyZone := map[string]interface{}{
+			"storage": map[string]interface{}{
+				"raft": map[string]interface{}{
+					"autopilot_redundancy_zone": "${.Env.VAULT_REDUNDANCY_ZONE}",
+				},
+			},
+		}
+
+		if err := mergo.Merge(&config, redundancyZone); err != nil {
+			return nil, err
+		}
+	}
+
+	// The address and CA certificate of the unsealing Vault are resolved by the config templating init container
+	if transit := vault.Spec.UnsealConfig.Transit; transit != nil {
+		config["seal"] = withTransitSeal(config["seal"], map[string]interface{}{
+			"address":     "${.Env.VAULT_TRANSIT_ADDRESS}",
+			"tls_ca_cert": "${.Env.VAULT_TRANSIT_CA_CERT}",
+			"mount_path":  transit.GetMountPath(),
+			"key_name":    transit.GetKeyName(vault),
+		})
+	}
+
+	// An explicitly configured retry_join stanza is never overwritten
+	if vault.Spec.IsRaftRetryJoin() {
+		retryJoin := map[string]interface{}{
+			"storage": map[string]interface{}{
+				"raft": map[string]interface{}{
+					"retry_join": vault.raftRetryJoin(),
+				},
+			},
+		}
+
+		if err := mergo.Merge(&config, retryJoin); err != nil {
+			return nil, err
+		}
+	}
+
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
@@ -1029,6 +1971,26 @@ func (vault *Vault) ConfigJSON() ([]byte, error) {
 	return configJSON, nil
 }
 
+// raftRetryJoin returns a retry_join stanza for every per-instance Vault Service
+func (vault *Vault) raftRetryJoin() []interface{} {
+	var retryJoin []interface{}
+	for i := 0; i < int(vault.Spec.Size); i++ {
+		host := fmt.Sprintf("%s-%d.%s", vault.Name, i, vault.Namespace)
+
+		leader := map[string]interface{}{
+			"leader_api_addr": fmt.Sprintf("%s://%s:8200", vault.Spec.GetAPIScheme(), host),
+		}
+
+		if !vault.Spec.IsTLSDisabled() {
+			leader["leader_ca_cert_file"] = "/vault/tls/ca.crt"
+			leader["leader_tls_servername"] = host
+		}
+
+		retryJoin = append(retryJoin, leader)
+	}
+	return retryJoin
+}
+
 // GetIngress the Ingress configuration for Vault if any
 func (vault *Vault) GetIngress() *Ingress {
 	if vault.Spec.Ingress != nil {
@@ -15,9 +15,13 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetVersion(t *testing.T) {
@@ -83,3 +87,137 @@ func TestGetConfigPath(t *testing.T) {
 		require.Equal(t, "/openbao/config", path)
 	})
 }
+
+func TestConfigJSONRaftRetryJoin(t *testing.T) {
+	newVault := func(config string) *Vault {
+		return &Vault{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vault",
+				Namespace: "default",
+			},
+			Spec: VaultSpec{
+				Size:                 3,
+				RaftRetryJoinEnabled: true,
+				Config:               extv1beta1.JSON{Raw: []byte(config)},
+			},
+		}
+	}
+
+	t.Run("Generated stanzas", func(t *testing.T) {
+		vault := newVault(`{"storage": {"raft": {"path": "/vault/file"}}}`)
+
+		configJSON, err := vault.ConfigJSON()
+		require.NoError(t, err)
+
+		var config map[string]interface{}
+		require.NoError(t, json.Unmarshal(configJSON, &config))
+
+		raft := config["storage"].(map[string]interface{})["raft"].(map[string]interface{})
+		require.Equal(t, "/vault/file", raft["path"])
+
+		retryJoin := raft["retry_join"].([]interface{})
+		require.Len(t, retryJoin, 3)
+		require.Equal(t, map[string]interface{}{
+			"leader_api_addr":       "https://vault-1.default:8200",
+			"leader_ca_cert_file":   "/vault/tls/ca.crt",
+			"leader_tls_servername": "vault-1.default",
+		}, retryJoin[1])
+	})
+
+	t.Run("TLS disabled", func(t *testing.T) {
+		vault := newVault(`{"storage": {"raft": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`)
+
+		configJSON, err := vault.ConfigJSON()
+		require.NoError(t, err)
+		require.Contains(t, string(configJSON), `{"leader_api_addr":"http://vault-0.default:8200"}`)
+	})
+
+	t.Run("Explicit stanza is kept", func(t *testing.T) {
+		vault := newVault(`{"storage": {"raft": {"retry_join": [{"leader_api_addr": "https://example.com:8200"}]}}}`)
+
+		configJSON, err := vault.ConfigJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"storage": {"raft": {"retry_join": [{"leader_api_addr": "https://example.com:8200"}]}}}`, string(configJSON))
+	})
+}
+
+func TestUnsealConfigSecretRefs(t *testing.T) {
+	vault := &Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"}}
+
+	usc := UnsealConfig{
+		HSM: &HSMUnsealConfig{
+			ModulePath: "/usr/lib/opensc-pkcs11.so",
+			KeyLabel:   "bank-vaults",
+			Pin:        "banzai",
+			PinSecretRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "hsm-pin"},
+				Key:                  "pin",
+			},
+		},
+	}
+
+	require.NotContains(t, usc.ToArgs(vault), "--hsm-pin")
+	require.Equal(t, []string{"unsealConfig.hsm.pin"}, usc.DeprecatedFields())
+
+	envs := usc.ToEnv()
+	require.Len(t, envs, 1)
+	require.Equal(t, "BANK_VAULTS_HSM_PIN", envs[0].Name)
+	require.Equal(t, "hsm-pin", envs[0].ValueFrom.SecretKeyRef.Name)
+}
+
+func TestGetSealType(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		sealType string
+	}{
+		{name: "no seal", config: `{"storage": {"raft": {}}}`, sealType: ShamirSealType},
+		{name: "seal", config: `{"seal": {"awskms": {"kms_key_id": "key"}}}`, sealType: "awskms"},
+		{name: "disabled seal", config: `{"seal": {"awskms": {"disabled": "true"}}}`, sealType: ShamirSealType},
+		{name: "seal list", config: `{"seal": [{"transit": {"disabled": true}}, {"awskms": {}}]}`, sealType: "awskms"},
+		{name: "migrating seals", config: `{"seal": {"transit": {"disabled": true}, "awskms": {}}}`, sealType: "awskms"},
+		{name: "disabled block list", config: `{"seal": {"awskms": [{"disabled": "true"}], "transit": [{}]}}`, sealType: "transit"},
+		{name: "several enabled seals", config: `{"seal": {"transit": {}, "awskms": {}}}`, sealType: "awskms"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := &VaultSpec{Config: extv1beta1.JSON{Raw: []byte(test.config)}}
+			require.Equal(t, test.sealType, spec.GetSealType())
+			require.Equal(t, test.sealType != ShamirSealType, spec.IsAutoUnseal())
+		})
+	}
+}
+
+func TestConfigJSONTransitSeal(t *testing.T) {
+	transit := `{"address": "${.Env.VAULT_TRANSIT_ADDRESS}", "tls_ca_cert": "${.Env.VAULT_TRANSIT_CA_CERT}", "mount_path": "transit", "key_name": "default-vault"}`
+
+	tests := []struct {
+		name   string
+		config string
+		seal   string
+	}{
+		{name: "no seal", config: `{}`, seal: `{"transit": ` + transit + `}`},
+		{name: "disabled seal is kept", config: `{"seal": {"awskms": {"kms_key_id": "key", "disabled": true}}}`, seal: `{"awskms": {"kms_key_id": "key", "disabled": true}, "transit": ` + transit + `}`},
+		{name: "seal list", config: `{"seal": [{"awskms": {"disabled": true}}, {"transit": {"address": "https://example.com"}}]}`, seal: `[{"awskms": {"disabled": true}}, {"transit": ` + transit + `}]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vault := &Vault{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+				Spec: VaultSpec{
+					Config:       extv1beta1.JSON{Raw: []byte(test.config)},
+					UnsealConfig: UnsealConfig{Transit: &TransitUnsealConfig{VaultRef: TransitVaultRef{Name: "transit"}}},
+				},
+			}
+
+			configJSON, err := vault.ConfigJSON()
+			require.NoError(t, err)
+
+			var config map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(configJSON, &config))
+			require.JSONEq(t, test.seal, string(config["seal"]))
+		})
+	}
+}
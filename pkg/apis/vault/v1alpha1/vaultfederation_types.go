@@ -0,0 +1,157 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultFederationSpec defines the desired state of a raft cluster spanning multiple Kubernetes clusters.
+// The same VaultFederation is expected to be applied to every member cluster, only LocalCluster differs.
+// The Vault CR of a member is not changed, the Vault controller applies the raft leader address and the CA bundle
+// of the bootstrap leader to the Vault Pods it creates, so raftLeaderAddress has to be left empty in the Vault CR.
+type VaultFederationSpec struct {
+	// VaultName is the name of the Vault CR in the namespace of the VaultFederation, which is the
+	// local member of the federated raft cluster.
+	VaultName string `json:"vaultName"`
+
+	// LocalCluster is the name of the member running in this Kubernetes cluster.
+	LocalCluster string `json:"localCluster"`
+
+	// BootstrapCluster is the name of the member which initializes the raft cluster, every other member joins it.
+	// default: the first member
+	BootstrapCluster string `json:"bootstrapCluster,omitempty"`
+
+	// Members lists the Kubernetes clusters of the federation.
+	Members []VaultFederationMember `json:"members"`
+}
+
+// VaultFederationMember is a Kubernetes cluster participating in a VaultFederation
+type VaultFederationMember struct {
+	// Name is the name of the member, it has to be unique in the federation.
+	Name string `json:"name"`
+
+	// Address is the host name or IP address the other members reach the Vault API (8200) and the raft
+	// cluster port (8201) of this member at, for example the DNS name of its LoadBalancer.
+	// Required for the bootstrap member, the other members join it at this address.
+	Address string `json:"address,omitempty"`
+
+	// KubeconfigSecretRef selects a kubeconfig in the namespace of the VaultFederation, which is used to read the
+	// member Secret published by the operator in the member cluster. If not set, the member Secret is looked up
+	// in the local cluster, so it can be synchronized by other means (or all members run in the same cluster).
+	KubeconfigSecretRef *v1.SecretKeySelector `json:"kubeconfigSecretRef,omitempty"`
+
+	// Namespace is the namespace of the VaultFederation in the member cluster.
+	// default: the namespace of the VaultFederation
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetBootstrapCluster returns the name of the member which initializes the raft cluster
+func (spec *VaultFederationSpec) GetBootstrapCluster() string {
+	if spec.BootstrapCluster != "" {
+		return spec.BootstrapCluster
+	}
+	if len(spec.Members) > 0 {
+		return spec.Members[0].Name
+	}
+	return ""
+}
+
+// IsBootstrapLeader checks if the local member initializes the raft cluster
+func (spec *VaultFederationSpec) IsBootstrapLeader() bool {
+	return spec.LocalCluster == spec.GetBootstrapCluster()
+}
+
+// Validate checks the members of the federation
+func (spec *VaultFederationSpec) Validate() error {
+	if spec.GetMember(spec.LocalCluster) == nil {
+		return fmt.Errorf("local cluster %q is not a member of the federation", spec.LocalCluster)
+	}
+
+	bootstrapMember := spec.GetMember(spec.GetBootstrapCluster())
+	if bootstrapMember == nil {
+		return fmt.Errorf("bootstrap cluster %q is not a member of the federation", spec.GetBootstrapCluster())
+	}
+	if bootstrapMember.Address == "" {
+		return fmt.Errorf("bootstrap cluster %q has no address the other members can reach it at", bootstrapMember.Name)
+	}
+
+	names := map[string]bool{}
+	for _, member := range spec.Members {
+		if names[member.Name] {
+			return fmt.Errorf("duplicate federation member %q", member.Name)
+		}
+		names[member.Name] = true
+	}
+
+	return nil
+}
+
+// GetMember returns the member with the given name
+func (spec *VaultFederationSpec) GetMember(name string) *VaultFederationMember {
+	for i := range spec.Members {
+		if spec.Members[i].Name == name {
+			return &spec.Members[i]
+		}
+	}
+	return nil
+}
+
+// VaultFederationMemberStatus is the observed state of a federation member
+type VaultFederationMemberStatus struct {
+	Name           string      `json:"name"`
+	LeaderAddress  string      `json:"leaderAddress,omitempty"`
+	Ready          bool        `json:"ready"`
+	Message        string      `json:"message,omitempty"`
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// VaultFederationStatus defines the observed state of VaultFederation
+type VaultFederationStatus struct {
+	BootstrapCluster string `json:"bootstrapCluster,omitempty"`
+	// LeaderAddress is the address of the bootstrap leader published by its member, the local Vault joins it
+	LeaderAddress string `json:"leaderAddress,omitempty"`
+	// LeaderCASecretName is the Secret holding the CA bundle of the bootstrap leader, mounted into the local Vault Pods
+	LeaderCASecretName string                        `json:"leaderCASecretName,omitempty"`
+	Members            []VaultFederationMemberStatus `json:"members,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultFederation is the Schema for the vaultfederations API
+type VaultFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultFederationSpec   `json:"spec,omitempty"`
+	Status VaultFederationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultFederationList contains a list of VaultFederation
+type VaultFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultFederation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultFederation{}, &VaultFederationList{})
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultMigrationSpec defines the desired state of VaultMigration
+type VaultMigrationSpec struct {
+	// Source is the Vault CR the data is migrated from, its storage backend has to be raft.
+	// It is hibernated once its snapshot is restored, delete it once the target is verified.
+	Source VaultReference `json:"source"`
+
+	// Target is the Vault CR the snapshot of the source is restored into. It has to use the raft storage backend,
+	// be initialized, and have the seal of the source. If the unseal keys of both are stored in Kubernetes Secrets,
+	// the keys of the source are copied to the target, otherwise they have to be stored at the same place.
+	// The seal can be migrated after the migration.
+	Target VaultReference `json:"target"`
+
+	// AliasService is an ExternalName Service the clients use, it is created pointing to the Service of the source,
+	// and switched to the Service of the target once the target is active with the restored data.
+	// default: no Service is switched
+	AliasService *AliasService `json:"aliasService,omitempty"`
+
+	// KeepSourceRunning leaves the source running after its snapshot is restored into the target, instead of
+	// hibernating it. The writes made to the source after the snapshot are not migrated, either way Vault can't
+	// refuse writes while it saves a snapshot, so stop the clients of the source before the migration.
+	// default: false
+	KeepSourceRunning bool `json:"keepSourceRunning,omitempty"`
+}
+
+// VaultReference references a Vault CR and a token to access it
+type VaultReference struct {
+	Name string `json:"name"`
+
+	// Namespace of the Vault CR.
+	// default: the namespace of the VaultMigration
+	Namespace string `json:"namespace,omitempty"`
+
+	// TokenSecretRef references a token allowed to save or restore raft snapshots, in the namespace of the Vault CR.
+	// default: the root token stored with the unseal keys in a Kubernetes Secret
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// AliasService is an ExternalName Service switched from the source to the target Vault
+type AliasService struct {
+	Name string `json:"name"`
+
+	// Namespace of the Service.
+	// default: the namespace of the VaultMigration
+	Namespace string `json:"namespace,omitempty"`
+
+	// ClusterDomain is the DNS domain of the Kubernetes cluster.
+	// default: cluster.local
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+}
+
+// GetClusterDomain returns the DNS domain of the Kubernetes cluster
+func (s *AliasService) GetClusterDomain() string {
+	if s.ClusterDomain != "" {
+		return s.ClusterDomain
+	}
+	return "cluster.local"
+}
+
+// VaultMigrationPhase is the phase of a VaultMigration
+type VaultMigrationPhase string
+
+const (
+	// VaultMigrationPending means that the migration waits for active nodes in the source and the target
+	VaultMigrationPending VaultMigrationPhase = "Pending"
+	// VaultMigrationRestored means that the snapshot of the source has been restored, the migration hibernates the source,
+	// copies the unseal keys, and waits for an active target
+	VaultMigrationRestored VaultMigrationPhase = "Restored"
+	// VaultMigrationCompleted means that the target is active with the restored data, and the alias Service points to it
+	VaultMigrationCompleted VaultMigrationPhase = "Completed"
+	// VaultMigrationFailed means that the migration has failed, create a new one to retry
+	VaultMigrationFailed VaultMigrationPhase = "Failed"
+)
+
+// VaultMigrationStatus defines the observed state of VaultMigration
+type VaultMigrationStatus struct {
+	Phase VaultMigrationPhase `json:"phase,omitempty"`
+	// SnapshotBytes is the size of the raft snapshot restored into the target
+	SnapshotBytes      int64       `json:"snapshotBytes,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultMigration is the Schema for the vaultmigrations API
+type VaultMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultMigrationSpec   `json:"spec,omitempty"`
+	Status VaultMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultMigrationList contains a list of VaultMigration
+type VaultMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultMigration{}, &VaultMigrationList{})
+}
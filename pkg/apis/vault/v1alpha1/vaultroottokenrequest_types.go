@@ -0,0 +1,92 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultRootTokenRequestSpec defines the desired state of VaultRootTokenRequest
+type VaultRootTokenRequestSpec struct {
+	// VaultName is the name of the Vault CR in the namespace of the VaultRootTokenRequest. Its unseal keys have
+	// to be stored in a Kubernetes Secret, they are used for the sys/generate-root flow.
+	VaultName string `json:"vaultName"`
+
+	// TTL is the lifetime of the delivered token, the Secret holding it is deleted when it expires.
+	// default: 1h
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// SecretName is the name of the Secret the token is delivered to.
+	// default: the name of the VaultRootTokenRequest
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// GetTTL returns the lifetime of the delivered token
+func (spec *VaultRootTokenRequestSpec) GetTTL() time.Duration {
+	if spec.TTL != nil && spec.TTL.Duration > 0 {
+		return spec.TTL.Duration
+	}
+	return time.Hour
+}
+
+// VaultRootTokenRequestPhase is the phase of a VaultRootTokenRequest
+type VaultRootTokenRequestPhase string
+
+const (
+	// VaultRootTokenRequestPending means that the request waits for an active Vault node
+	VaultRootTokenRequestPending VaultRootTokenRequestPhase = "Pending"
+	// VaultRootTokenRequestReady means that the token has been delivered to the Secret
+	VaultRootTokenRequestReady VaultRootTokenRequestPhase = "Ready"
+	// VaultRootTokenRequestExpired means that the token has expired, and the Secret has been deleted
+	VaultRootTokenRequestExpired VaultRootTokenRequestPhase = "Expired"
+	// VaultRootTokenRequestFailed means that the token couldn't be generated, create a new request to retry
+	VaultRootTokenRequestFailed VaultRootTokenRequestPhase = "Failed"
+)
+
+// VaultRootTokenRequestStatus defines the observed state of VaultRootTokenRequest
+type VaultRootTokenRequestStatus struct {
+	Phase          VaultRootTokenRequestPhase `json:"phase,omitempty"`
+	SecretName     string                     `json:"secretName,omitempty"`
+	Accessor       string                     `json:"accessor,omitempty"`
+	ExpirationTime metav1.Time                `json:"expirationTime,omitempty"`
+	Message        string                     `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultRootTokenRequest is the Schema for the vaultroottokenrequests API
+type VaultRootTokenRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultRootTokenRequestSpec   `json:"spec,omitempty"`
+	Status VaultRootTokenRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultRootTokenRequestList contains a list of VaultRootTokenRequest
+type VaultRootTokenRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultRootTokenRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultRootTokenRequest{}, &VaultRootTokenRequestList{})
+}
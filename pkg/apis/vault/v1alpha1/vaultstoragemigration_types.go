@@ -0,0 +1,91 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultStorageMigrationSpec defines the desired state of VaultStorageMigration
+type VaultStorageMigrationSpec struct {
+	// SourceVaultName is the name of the Vault CR to migrate from, in the namespace of the VaultStorageMigration.
+	// Its storage backend can be anything but raft, for example file or consul. It is hibernated for the migration,
+	// and left hibernated once the migration has completed.
+	SourceVaultName string `json:"sourceVaultName"`
+
+	// DestinationVaultName is the name of the Vault CR with the raft storage backend to migrate to. It has to be
+	// created with hibernate: true, so it doesn't get initialized before the migration, it is woken up afterwards.
+	// Its unseal keys have to be stored where the keys of the source are, or both have to be stored in Kubernetes
+	// Secrets, in which case the keys of the source are copied. It has to use the seal of the source as well,
+	// both are checked before the source is hibernated.
+	DestinationVaultName string `json:"destinationVaultName"`
+
+	// Image is the Vault image running vault operator migrate.
+	// default: the Vault image of the source
+	Image string `json:"image,omitempty"`
+}
+
+// VaultStorageMigrationPhase is the phase of a VaultStorageMigration
+type VaultStorageMigrationPhase string
+
+const (
+	// VaultStorageMigrationScalingDown means that the source Vault is hibernated, the migration waits for its Pods to stop
+	VaultStorageMigrationScalingDown VaultStorageMigrationPhase = "ScalingDownSource"
+	// VaultStorageMigrationMigrating means that the migration Job is running
+	VaultStorageMigrationMigrating VaultStorageMigrationPhase = "Migrating"
+	// VaultStorageMigrationStartingDestination means that the destination Vault is woken up, the migration waits for an active node
+	VaultStorageMigrationStartingDestination VaultStorageMigrationPhase = "StartingDestination"
+	// VaultStorageMigrationCompleted means that the destination Vault is active with the migrated data
+	VaultStorageMigrationCompleted VaultStorageMigrationPhase = "Completed"
+	// VaultStorageMigrationFailed means that the migration has failed, the source Vault is woken up again if it was hibernated
+	VaultStorageMigrationFailed VaultStorageMigrationPhase = "Failed"
+)
+
+// VaultStorageMigrationStatus defines the observed state of VaultStorageMigration
+type VaultStorageMigrationStatus struct {
+	Phase VaultStorageMigrationPhase `json:"phase,omitempty"`
+	Job   string                     `json:"job,omitempty"`
+	// MigratedKeys is the number of keys copied from the source storage
+	MigratedKeys int `json:"migratedKeys,omitempty"`
+	// DestinationKeys is the number of keys read back from the destination storage, it matches MigratedKeys
+	DestinationKeys    int         `json:"destinationKeys,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultStorageMigration is the Schema for the vaultstoragemigrations API
+type VaultStorageMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultStorageMigrationSpec   `json:"spec,omitempty"`
+	Status VaultStorageMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultStorageMigrationList contains a list of VaultStorageMigration
+type VaultStorageMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VaultStorageMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultStorageMigration{}, &VaultStorageMigrationList{})
+}
@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -38,6 +39,21 @@ func (in *AWSUnsealConfig) DeepCopy() *AWSUnsealConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasService) DeepCopyInto(out *AliasService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasService.
+func (in *AliasService) DeepCopy() *AliasService {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlibabaUnsealConfig) DeepCopyInto(out *AlibabaUnsealConfig) {
 	*out = *in
@@ -68,6 +84,21 @@ func (in *AzureUnsealConfig) DeepCopy() *AzureUnsealConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimExpansionStatus) DeepCopyInto(out *ClaimExpansionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimExpansionStatus.
+func (in *ClaimExpansionStatus) DeepCopy() *ClaimExpansionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimExpansionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialsConfig) DeepCopyInto(out *CredentialsConfig) {
 	*out = *in
@@ -305,6 +336,45 @@ func (in *EmbeddedPodSpec) DeepCopy() *EmbeddedPodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotation) DeepCopyInto(out *EncryptionKeyRotation) {
+	*out = *in
+	out.Period = in.Period
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotation.
+func (in *EncryptionKeyRotation) DeepCopy() *EncryptionKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyStatus) DeepCopyInto(out *EncryptionKeyStatus) {
+	*out = *in
+	in.InstallTime.DeepCopyInto(&out.InstallTime)
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	in.NextRotationTime.DeepCopyInto(&out.NextRotationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyStatus.
+func (in *EncryptionKeyStatus) DeepCopy() *EncryptionKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GoogleUnsealConfig) DeepCopyInto(out *GoogleUnsealConfig) {
 	*out = *in
@@ -320,9 +390,39 @@ func (in *GoogleUnsealConfig) DeepCopy() *GoogleUnsealConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulShutdown) DeepCopyInto(out *GracefulShutdown) {
+	*out = *in
+	if in.StepDownTokenSecretRef != nil {
+		in, out := &in.StepDownTokenSecretRef, &out.StepDownTokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdown.
+func (in *GracefulShutdown) DeepCopy() *GracefulShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HSMUnsealConfig) DeepCopyInto(out *HSMUnsealConfig) {
 	*out = *in
+	if in.PinSecretRef != nil {
+		in, out := &in.PinSecretRef, &out.PinSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSMUnsealConfig.
@@ -373,6 +473,49 @@ func (in *KubernetesUnsealConfig) DeepCopy() *KubernetesUnsealConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	if in.PendingSince != nil {
+		in, out := &in.PendingSince, &out.PendingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.NextWindow != nil {
+		in, out := &in.NextWindow, &out.NextWindow
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIUnsealConfig) DeepCopyInto(out *OCIUnsealConfig) {
 	*out = *in
@@ -388,6 +531,110 @@ func (in *OCIUnsealConfig) DeepCopy() *OCIUnsealConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorUnsealStatus) DeepCopyInto(out *OperatorUnsealStatus) {
+	*out = *in
+	if in.SealedPods != nil {
+		in, out := &in.SealedPods, &out.SealedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorUnsealStatus.
+func (in *OperatorUnsealStatus) DeepCopy() *OperatorUnsealStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorUnsealStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGPKey) DeepCopyInto(out *PGPKey) {
+	*out = *in
+	if in.PublicKeySecretRef != nil {
+		in, out := &in.PublicKeySecretRef, &out.PublicKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGPKey.
+func (in *PGPKey) DeepCopy() *PGPKey {
+	if in == nil {
+		return nil
+	}
+	out := new(PGPKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftRecovery) DeepCopyInto(out *RaftRecovery) {
+	*out = *in
+	if in.Survivors != nil {
+		in, out := &in.Survivors, &out.Survivors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeIDs != nil {
+		in, out := &in.NodeIDs, &out.NodeIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftRecovery.
+func (in *RaftRecovery) DeepCopy() *RaftRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaftRecoveryStatus) DeepCopyInto(out *RaftRecoveryStatus) {
+	*out = *in
+	if in.Survivors != nil {
+		in, out := &in.Survivors, &out.Survivors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaftRecoveryStatus.
+func (in *RaftRecoveryStatus) DeepCopy() *RaftRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RaftRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RekeyStatus) DeepCopyInto(out *RekeyStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RekeyStatus.
+func (in *RekeyStatus) DeepCopy() *RekeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RekeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
@@ -429,151 +676,865 @@ func (in *Resources) DeepCopy() *Resources {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UnsealConfig) DeepCopyInto(out *UnsealConfig) {
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
 	*out = *in
-	in.Options.DeepCopyInto(&out.Options)
-	out.Kubernetes = in.Kubernetes
-	if in.Google != nil {
-		in, out := &in.Google, &out.Google
-		*out = new(GoogleUnsealConfig)
-		**out = **in
-	}
-	if in.Alibaba != nil {
-		in, out := &in.Alibaba, &out.Alibaba
-		*out = new(AlibabaUnsealConfig)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureUnsealConfig)
-		**out = **in
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSUnsealConfig)
-		**out = **in
-	}
-	if in.OCI != nil {
-		in, out := &in.OCI, &out.OCI
-		*out = new(OCIUnsealConfig)
-		**out = **in
-	}
-	if in.Vault != nil {
-		in, out := &in.Vault, &out.Vault
-		*out = new(VaultUnsealConfig)
-		**out = **in
-	}
-	if in.HSM != nil {
-		in, out := &in.HSM, &out.HSM
-		*out = new(HSMUnsealConfig)
-		**out = **in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
 	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealConfig.
-func (in *UnsealConfig) DeepCopy() *UnsealConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(UnsealConfig)
+	out := new(RolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UnsealOptions) DeepCopyInto(out *UnsealOptions) {
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	*out = *in
-	if in.PreFlightChecks != nil {
-		in, out := &in.PreFlightChecks, &out.PreFlightChecks
-		*out = new(bool)
-		**out = **in
-	}
-	if in.StoreRootToken != nil {
-		in, out := &in.StoreRootToken, &out.StoreRootToken
-		*out = new(bool)
+	if in.Canaries != nil {
+		in, out := &in.Canaries, &out.Canaries
+		*out = new(int32)
 		**out = **in
 	}
-	if in.SecretThreshold != nil {
-		in, out := &in.SecretThreshold, &out.SecretThreshold
-		*out = new(uint)
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.SecretShares != nil {
-		in, out := &in.SecretShares, &out.SecretShares
-		*out = new(uint)
+	if in.HealthGateTimeout != nil {
+		in, out := &in.HealthGateTimeout, &out.HealthGateTimeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealOptions.
-func (in *UnsealOptions) DeepCopy() *UnsealOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(UnsealOptions)
+	out := new(RolloutStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vault) DeepCopyInto(out *Vault) {
+func (in *RootTokenStatus) DeepCopyInto(out *RootTokenStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.RevocationTime.DeepCopyInto(&out.RevocationTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
-func (in *Vault) DeepCopy() *Vault {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootTokenStatus.
+func (in *RootTokenStatus) DeepCopy() *RootTokenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Vault)
+	out := new(RootTokenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Vault) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultList) DeepCopyInto(out *VaultList) {
+func (in *SealMigrationStatus) DeepCopyInto(out *SealMigrationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Vault, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MigratedPods != nil {
+		in, out := &in.MigratedPods, &out.MigratedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultList.
-func (in *VaultList) DeepCopy() *VaultList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealMigrationStatus.
+func (in *SealMigrationStatus) DeepCopy() *SealMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultList)
+	out := new(SealMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealedPodHealing) DeepCopyInto(out *SealedPodHealing) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RestartBackoff != nil {
+		in, out := &in.RestartBackoff, &out.RestartBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealedPodHealing.
+func (in *SealedPodHealing) DeepCopy() *SealedPodHealing {
+	if in == nil {
+		return nil
+	}
+	out := new(SealedPodHealing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealedPodStatus) DeepCopyInto(out *SealedPodStatus) {
+	*out = *in
+	in.SealedSince.DeepCopyInto(&out.SealedSince)
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealedPodStatus.
+func (in *SealedPodStatus) DeepCopy() *SealedPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SealedPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitUnsealConfig) DeepCopyInto(out *TransitUnsealConfig) {
+	*out = *in
+	out.VaultRef = in.VaultRef
+	if in.TokenPeriod != nil {
+		in, out := &in.TokenPeriod, &out.TokenPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TokenRotationPeriod != nil {
+		in, out := &in.TokenRotationPeriod, &out.TokenRotationPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitUnsealConfig.
+func (in *TransitUnsealConfig) DeepCopy() *TransitUnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitUnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitUnsealStatus) DeepCopyInto(out *TransitUnsealStatus) {
+	*out = *in
+	in.TokenIssueTime.DeepCopyInto(&out.TokenIssueTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitUnsealStatus.
+func (in *TransitUnsealStatus) DeepCopy() *TransitUnsealStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitUnsealStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitVaultRef) DeepCopyInto(out *TransitVaultRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitVaultRef.
+func (in *TransitVaultRef) DeepCopy() *TransitVaultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitVaultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealConfig) DeepCopyInto(out *UnsealConfig) {
+	*out = *in
+	in.Options.DeepCopyInto(&out.Options)
+	out.Kubernetes = in.Kubernetes
+	if in.Google != nil {
+		in, out := &in.Google, &out.Google
+		*out = new(GoogleUnsealConfig)
+		**out = **in
+	}
+	if in.Alibaba != nil {
+		in, out := &in.Alibaba, &out.Alibaba
+		*out = new(AlibabaUnsealConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureUnsealConfig)
+		**out = **in
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSUnsealConfig)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIUnsealConfig)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSM != nil {
+		in, out := &in.HSM, &out.HSM
+		*out = new(HSMUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transit != nil {
+		in, out := &in.Transit, &out.Transit
+		*out = new(TransitUnsealConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]KubernetesUnsealConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.Escrow != nil {
+		in, out := &in.Escrow, &out.Escrow
+		*out = new(UnsealEscrow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealConfig.
+func (in *UnsealConfig) DeepCopy() *UnsealConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealEscrow) DeepCopyInto(out *UnsealEscrow) {
+	*out = *in
+	if in.KeyHolders != nil {
+		in, out := &in.KeyHolders, &out.KeyHolders
+		*out = make([]UnsealEscrowKeyHolder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RootTokenPGPKey != nil {
+		in, out := &in.RootTokenPGPKey, &out.RootTokenPGPKey
+		*out = new(PGPKey)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealEscrow.
+func (in *UnsealEscrow) DeepCopy() *UnsealEscrow {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealEscrow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealEscrowKeyHolder) DeepCopyInto(out *UnsealEscrowKeyHolder) {
+	*out = *in
+	in.PGPKey.DeepCopyInto(&out.PGPKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealEscrowKeyHolder.
+func (in *UnsealEscrowKeyHolder) DeepCopy() *UnsealEscrowKeyHolder {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealEscrowKeyHolder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealEscrowStatus) DeepCopyInto(out *UnsealEscrowStatus) {
+	*out = *in
+	if in.KeyHolders != nil {
+		in, out := &in.KeyHolders, &out.KeyHolders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SealedPods != nil {
+		in, out := &in.SealedPods, &out.SealedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealEscrowStatus.
+func (in *UnsealEscrowStatus) DeepCopy() *UnsealEscrowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealEscrowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealKeyMirrorStatus) DeepCopyInto(out *UnsealKeyMirrorStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealKeyMirrorStatus.
+func (in *UnsealKeyMirrorStatus) DeepCopy() *UnsealKeyMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealKeyMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealOptions) DeepCopyInto(out *UnsealOptions) {
+	*out = *in
+	if in.PreFlightChecks != nil {
+		in, out := &in.PreFlightChecks, &out.PreFlightChecks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoreRootToken != nil {
+		in, out := &in.StoreRootToken, &out.StoreRootToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RevokeRootToken != nil {
+		in, out := &in.RevokeRootToken, &out.RevokeRootToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretThreshold != nil {
+		in, out := &in.SecretThreshold, &out.SecretThreshold
+		*out = new(uint)
+		**out = **in
+	}
+	if in.SecretShares != nil {
+		in, out := &in.SecretShares, &out.SecretShares
+		*out = new(uint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealOptions.
+func (in *UnsealOptions) DeepCopy() *UnsealOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeSnapshot) DeepCopyInto(out *UpgradeSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSnapshot.
+func (in *UpgradeSnapshot) DeepCopy() *UpgradeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(UpgradeSnapshot)
+		**out = **in
+	}
+	if in.HealthTimeout != nil {
+		in, out := &in.HealthTimeout, &out.HealthTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vault) DeepCopyInto(out *Vault) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vault.
+func (in *Vault) DeepCopy() *Vault {
+	if in == nil {
+		return nil
+	}
+	out := new(Vault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vault) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederation) DeepCopyInto(out *VaultFederation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederation.
+func (in *VaultFederation) DeepCopy() *VaultFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederationList) DeepCopyInto(out *VaultFederationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederationList.
+func (in *VaultFederationList) DeepCopy() *VaultFederationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederationMember) DeepCopyInto(out *VaultFederationMember) {
+	*out = *in
+	if in.KubeconfigSecretRef != nil {
+		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederationMember.
+func (in *VaultFederationMember) DeepCopy() *VaultFederationMember {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederationMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederationMemberStatus) DeepCopyInto(out *VaultFederationMemberStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederationMemberStatus.
+func (in *VaultFederationMemberStatus) DeepCopy() *VaultFederationMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederationMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederationSpec) DeepCopyInto(out *VaultFederationSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]VaultFederationMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederationSpec.
+func (in *VaultFederationSpec) DeepCopy() *VaultFederationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultFederationStatus) DeepCopyInto(out *VaultFederationStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]VaultFederationMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultFederationStatus.
+func (in *VaultFederationStatus) DeepCopy() *VaultFederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultFederationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultList) DeepCopyInto(out *VaultList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultList.
+func (in *VaultList) DeepCopy() *VaultList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMigration) DeepCopyInto(out *VaultMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMigration.
+func (in *VaultMigration) DeepCopy() *VaultMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMigrationList) DeepCopyInto(out *VaultMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMigrationList.
+func (in *VaultMigrationList) DeepCopy() *VaultMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMigrationSpec) DeepCopyInto(out *VaultMigrationSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.Target.DeepCopyInto(&out.Target)
+	if in.AliasService != nil {
+		in, out := &in.AliasService, &out.AliasService
+		*out = new(AliasService)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMigrationSpec.
+func (in *VaultMigrationSpec) DeepCopy() *VaultMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMigrationStatus) DeepCopyInto(out *VaultMigrationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMigrationStatus.
+func (in *VaultMigrationStatus) DeepCopy() *VaultMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultReference) DeepCopyInto(out *VaultReference) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultReference.
+func (in *VaultReference) DeepCopy() *VaultReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRootTokenRequest) DeepCopyInto(out *VaultRootTokenRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRootTokenRequest.
+func (in *VaultRootTokenRequest) DeepCopy() *VaultRootTokenRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRootTokenRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultRootTokenRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRootTokenRequestList) DeepCopyInto(out *VaultRootTokenRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultRootTokenRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRootTokenRequestList.
+func (in *VaultRootTokenRequestList) DeepCopy() *VaultRootTokenRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRootTokenRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultRootTokenRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRootTokenRequestSpec) DeepCopyInto(out *VaultRootTokenRequestSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRootTokenRequestSpec.
+func (in *VaultRootTokenRequestSpec) DeepCopy() *VaultRootTokenRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRootTokenRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRootTokenRequestStatus) DeepCopyInto(out *VaultRootTokenRequestStatus) {
+	*out = *in
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRootTokenRequestStatus.
+func (in *VaultRootTokenRequestStatus) DeepCopy() *VaultRootTokenRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRootTokenRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 	*out = *in
@@ -668,6 +1629,48 @@ func (in *VaultSpec) DeepCopyInto(out *VaultSpec) {
 		}
 	}
 	in.SecurityContext.DeepCopyInto(&out.SecurityContext)
+	if in.RaftRecovery != nil {
+		in, out := &in.RaftRecovery, &out.RaftRecovery
+		*out = new(RaftRecovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncryptionKeyRotation != nil {
+		in, out := &in.EncryptionKeyRotation, &out.EncryptionKeyRotation
+		*out = new(EncryptionKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SealedPodHealing != nil {
+		in, out := &in.SealedPodHealing, &out.SealedPodHealing
+		*out = new(SealedPodHealing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(GracefulShutdown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VersionPolicy != nil {
+		in, out := &in.VersionPolicy, &out.VersionPolicy
+		*out = new(VersionPolicy)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServicePorts != nil {
 		in, out := &in.ServicePorts, &out.ServicePorts
 		*out = make(map[string]int32, len(*in))
@@ -796,6 +1799,90 @@ func (in *VaultStatus) DeepCopyInto(out *VaultStatus) {
 		*out = make([]v1.ComponentCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.RaftRecovery != nil {
+		in, out := &in.RaftRecovery, &out.RaftRecovery
+		*out = new(RaftRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeprecatedFields != nil {
+		in, out := &in.DeprecatedFields, &out.DeprecatedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsealKeyMirrors != nil {
+		in, out := &in.UnsealKeyMirrors, &out.UnsealKeyMirrors
+		*out = make([]UnsealKeyMirrorStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Escrow != nil {
+		in, out := &in.Escrow, &out.Escrow
+		*out = new(UnsealEscrowStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rekey != nil {
+		in, out := &in.Rekey, &out.Rekey
+		*out = new(RekeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncryptionKey != nil {
+		in, out := &in.EncryptionKey, &out.EncryptionKey
+		*out = new(EncryptionKeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RootToken != nil {
+		in, out := &in.RootToken, &out.RootToken
+		*out = new(RootTokenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SealMigration != nil {
+		in, out := &in.SealMigration, &out.SealMigration
+		*out = new(SealMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitUnseal != nil {
+		in, out := &in.TransitUnseal, &out.TransitUnseal
+		*out = new(TransitUnsealStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Unseal != nil {
+		in, out := &in.Unseal, &out.Unseal
+		*out = new(OperatorUnsealStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SealedPods != nil {
+		in, out := &in.SealedPods, &out.SealedPods
+		*out = make([]SealedPodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(VersionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeExpansion != nil {
+		in, out := &in.VolumeExpansion, &out.VolumeExpansion
+		*out = new(VolumeExpansionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStatus.
@@ -808,9 +1895,104 @@ func (in *VaultStatus) DeepCopy() *VaultStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStorageMigration) DeepCopyInto(out *VaultStorageMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStorageMigration.
+func (in *VaultStorageMigration) DeepCopy() *VaultStorageMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStorageMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultStorageMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStorageMigrationList) DeepCopyInto(out *VaultStorageMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultStorageMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStorageMigrationList.
+func (in *VaultStorageMigrationList) DeepCopy() *VaultStorageMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStorageMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultStorageMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStorageMigrationSpec) DeepCopyInto(out *VaultStorageMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStorageMigrationSpec.
+func (in *VaultStorageMigrationSpec) DeepCopy() *VaultStorageMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStorageMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStorageMigrationStatus) DeepCopyInto(out *VaultStorageMigrationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStorageMigrationStatus.
+func (in *VaultStorageMigrationStatus) DeepCopy() *VaultStorageMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStorageMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultUnsealConfig) DeepCopyInto(out *VaultUnsealConfig) {
 	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealConfig.
@@ -822,3 +2004,77 @@ func (in *VaultUnsealConfig) DeepCopy() *VaultUnsealConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionHistoryEntry) DeepCopyInto(out *VersionHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionHistoryEntry.
+func (in *VersionHistoryEntry) DeepCopy() *VersionHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionPolicy) DeepCopyInto(out *VersionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionPolicy.
+func (in *VersionPolicy) DeepCopy() *VersionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionStatus) DeepCopyInto(out *VersionStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]VersionHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionStatus.
+func (in *VersionStatus) DeepCopy() *VersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExpansionStatus) DeepCopyInto(out *VolumeExpansionStatus) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]ClaimExpansionStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExpansionStatus.
+func (in *VolumeExpansionStatus) DeepCopy() *VolumeExpansionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExpansionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
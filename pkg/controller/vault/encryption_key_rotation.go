@@ -0,0 +1,163 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	encryptionKeyRotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vault_operator_encryption_key_rotations_total",
+		Help: "Number of barrier encryption key rotations run by the operator",
+	}, []string{"namespace", "name", "result"})
+
+	encryptionKeyTerm = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vault_operator_encryption_key_term",
+		Help: "Term of the active barrier encryption key",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(encryptionKeyRotations, encryptionKeyTerm)
+}
+
+// reconcileEncryptionKeyRotation rotates the barrier encryption key on the active Vault node when it's older than the period
+func (r *ReconcileVault) reconcileEncryptionKeyRotation(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	rotation := v.Spec.EncryptionKeyRotation
+
+	status := &vaultv1alpha1.EncryptionKeyStatus{}
+	if v.Status.EncryptionKey != nil {
+		status.LastRotationTime = v.Status.EncryptionKey.LastRotationTime
+	}
+
+	if rotation.Period.Duration <= 0 {
+		status.Message = "the encryption key rotation period must be positive"
+		return r.setEncryptionKeyStatus(ctx, v, status)
+	}
+
+	vaultClient, err := ActiveVaultClient(ctx, r.client, v)
+	if err != nil || vaultClient == nil {
+		// No active node to talk to, will be checked again in the next reconcile
+		return err
+	}
+
+	token, err := r.encryptionKeyRotationToken(ctx, v)
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to get Vault token: %v", err)
+		return r.setEncryptionKeyStatus(ctx, v, status)
+	}
+	vaultClient.SetToken(token)
+
+	keyStatus, err := vaultClient.Sys().KeyStatus()
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to get key status: %v", err)
+		return r.setEncryptionKeyStatus(ctx, v, status)
+	}
+
+	if !time.Now().Before(keyStatus.InstallTime.Add(rotation.Period.Duration)) {
+		if err := vaultClient.Sys().Rotate(); err != nil {
+			encryptionKeyRotations.WithLabelValues(v.Namespace, v.Name, "failure").Inc()
+			status.Message = fmt.Sprintf("failed to rotate encryption key: %v", err)
+			r.recorder.Event(v, corev1.EventTypeWarning, "EncryptionKeyRotationFailed", status.Message)
+			return r.setEncryptionKeyStatus(ctx, v, status)
+		}
+
+		encryptionKeyRotations.WithLabelValues(v.Namespace, v.Name, "success").Inc()
+		status.LastRotationTime = metav1.Now()
+
+		keyStatus, err = vaultClient.Sys().KeyStatus()
+		if err != nil {
+			status.Message = fmt.Sprintf("failed to get key status: %v", err)
+			return r.setEncryptionKeyStatus(ctx, v, status)
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "EncryptionKeyRotated", "barrier encryption key rotated to term %d", keyStatus.Term)
+	}
+
+	encryptionKeyTerm.WithLabelValues(v.Namespace, v.Name).Set(float64(keyStatus.Term))
+
+	status.Term = keyStatus.Term
+	status.InstallTime = metav1.NewTime(keyStatus.InstallTime)
+	status.NextRotationTime = metav1.NewTime(keyStatus.InstallTime.Add(rotation.Period.Duration))
+
+	return r.setEncryptionKeyStatus(ctx, v, status)
+}
+
+// encryptionKeyRotationToken returns the Vault token used to rotate the encryption key
+func (r *ReconcileVault) encryptionKeyRotationToken(ctx context.Context, v *vaultv1alpha1.Vault) (string, error) {
+	return r.vaultToken(ctx, v, v.Spec.EncryptionKeyRotation.TokenSecretRef)
+}
+
+// vaultToken returns the Vault token selected by the reference, or the root token stored in the Kubernetes Secret
+// of the unseal keys if there is no reference. The reference is required if the root token is revoked.
+func (r *ReconcileVault) vaultToken(ctx context.Context, v *vaultv1alpha1.Vault, ref *corev1.SecretKeySelector) (string, error) {
+	if ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: ref.Name}, secret); err != nil {
+			return "", err
+		}
+		token, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	if v.Spec.UnsealConfig.RevokesRootToken() {
+		return "", fmt.Errorf("the root token is revoked, set tokenSecretRef")
+	}
+
+	namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	if !ok {
+		return "", fmt.Errorf("the root token is not stored in a Kubernetes Secret, set tokenSecretRef")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return "", err
+	}
+	token, ok := secret.Data["vault-root"]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s holds no root token, set tokenSecretRef", namespace, name)
+	}
+	return string(token), nil
+}
+
+func (r *ReconcileVault) setEncryptionKeyStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.EncryptionKeyStatus) error {
+	if v.Status.EncryptionKey != nil && v.Status.EncryptionKey.Term == status.Term &&
+		v.Status.EncryptionKey.InstallTime.Equal(&status.InstallTime) &&
+		v.Status.EncryptionKey.LastRotationTime.Equal(&status.LastRotationTime) &&
+		v.Status.EncryptionKey.NextRotationTime.Equal(&status.NextRotationTime) &&
+		v.Status.EncryptionKey.Message == status.Message {
+		return nil
+	}
+
+	v.Status.EncryptionKey = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.EncryptionKey = status
+	})
+}
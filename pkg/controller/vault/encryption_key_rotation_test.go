@@ -0,0 +1,78 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEncryptionKeyRotationToken(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			EncryptionKeyRotation: &vaultv1alpha1.EncryptionKeyRotation{},
+		},
+	}
+
+	reconciler, _ := newTestReconciler(
+		v,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+			Data:       map[string][]byte{"vault-root": []byte("root")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "rotation-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("rotator\n")},
+		},
+	)
+
+	// The root token is used by default
+	token, err := reconciler.encryptionKeyRotationToken(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, "root", token)
+
+	v.Spec.EncryptionKeyRotation.TokenSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "rotation-token"},
+		Key:                  "token",
+	}
+	token, err = reconciler.encryptionKeyRotationToken(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, "rotator", token)
+
+	// A revoked root token is never the default
+	revoke := true
+	v.Spec.UnsealConfig.Options.RevokeRootToken = &revoke
+	token, err = reconciler.encryptionKeyRotationToken(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, "rotator", token)
+
+	v.Spec.EncryptionKeyRotation.TokenSecretRef = nil
+	_, err = reconciler.encryptionKeyRotationToken(ctx, v)
+	assert.EqualError(t, err, "the root token is revoked, set tokenSecretRef")
+
+	// An invalid period is reported before Vault is contacted
+	require.NoError(t, reconciler.reconcileEncryptionKeyRotation(ctx, v))
+	require.NotNil(t, v.Status.EncryptionKey)
+	assert.Equal(t, "the encryption key rotation period must be positive", v.Status.EncryptionKey.Message)
+}
@@ -0,0 +1,33 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"github.com/bank-vaults/vault-operator/pkg/controller/internal/testutil"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newTestReconciler returns a ReconcileVault with a fake client holding the given objects, and a fake event recorder
+func newTestReconciler(objects ...client.Object) (*ReconcileVault, client.Client) {
+	c, scheme := testutil.NewFakeClient(objects...)
+
+	return &ReconcileVault{
+		client:              c,
+		nonNamespacedClient: c,
+		scheme:              scheme,
+		recorder:            record.NewFakeRecorder(10),
+	}, c
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const stepDownTokenMountPath = "/vault/step-down"
+
+func stepDownEnabled(v *vaultv1alpha1.Vault) bool {
+	return v.Spec.GracefulShutdown != nil && v.Spec.GracefulShutdown.StepDownTokenSecretRef != nil
+}
+
+// withStepDownLifecycle steps down the Vault container in a preStop hook, if it is the active node.
+// A step-down request sent to a standby would be forwarded to the active node, so it is checked first.
+func withStepDownLifecycle(v *vaultv1alpha1.Vault) *corev1.Lifecycle {
+	if !stepDownEnabled(v) {
+		return nil
+	}
+
+	script := fmt.Sprintf(`export VAULT_ADDR=%s://127.0.0.1:8200 VAULT_SKIP_VERIFY=true VAULT_TOKEN="$(cat %s/token)"; `+
+		`if vault status -format=json | grep -q '"is_self": true'; then vault operator step-down; fi; true`,
+		strings.ToLower(string(getVaultURIScheme(v))), stepDownTokenMountPath)
+
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/sh", "-c", script},
+			},
+		},
+	}
+}
+
+func withStepDownVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if !stepDownEnabled(v) {
+		return volumes
+	}
+
+	ref := v.Spec.GracefulShutdown.StepDownTokenSecretRef
+	return append(volumes, corev1.Volume{
+		Name: "vault-step-down",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items: []corev1.KeyToPath{{
+					Key:  ref.Key,
+					Path: "token",
+				}},
+			},
+		},
+	})
+}
+
+func withStepDownVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if !stepDownEnabled(v) {
+		return volumeMounts
+	}
+
+	return append(volumeMounts, corev1.VolumeMount{
+		Name:      "vault-step-down",
+		MountPath: stepDownTokenMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// getTerminationGracePeriodSeconds returns the termination grace period of the Vault Pods, nil means the Kubernetes default
+func getTerminationGracePeriodSeconds(v *vaultv1alpha1.Vault) *int64 {
+	if v.Spec.GracefulShutdown == nil {
+		return nil
+	}
+	return v.Spec.GracefulShutdown.TerminationGracePeriodSeconds
+}
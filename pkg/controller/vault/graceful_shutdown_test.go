@@ -0,0 +1,75 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 3,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+		},
+	}
+
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+	assert.Nil(t, statefulSet.Spec.Template.Spec.Containers[0].Lifecycle)
+	assert.Nil(t, statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	v.Spec.GracefulShutdown = &vaultv1alpha1.GracefulShutdown{
+		StepDownTokenSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "vault-step-down"},
+			Key:                  "token",
+		},
+		TerminationGracePeriodSeconds: ptr.To(int64(60)),
+	}
+
+	statefulSet, err = statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+	podSpec := statefulSet.Spec.Template.Spec
+
+	vault := podSpec.Containers[0]
+	require.Equal(t, "vault", vault.Name)
+	require.NotNil(t, vault.Lifecycle)
+	script := vault.Lifecycle.PreStop.Exec.Command[2]
+	assert.Contains(t, script, "VAULT_ADDR=https://127.0.0.1:8200")
+	assert.Contains(t, script, `grep -q '"is_self": true'`)
+	assert.Contains(t, script, "vault operator step-down")
+	assert.Contains(t, vault.VolumeMounts, corev1.VolumeMount{Name: "vault-step-down", MountPath: stepDownTokenMountPath, ReadOnly: true})
+	assert.Equal(t, ptr.To(int64(60)), podSpec.TerminationGracePeriodSeconds)
+
+	var found bool
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == "vault-step-down" {
+			found = true
+			assert.Equal(t, "vault-step-down", volume.Secret.SecretName)
+		}
+	}
+	assert.True(t, found)
+}
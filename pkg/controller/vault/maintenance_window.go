@@ -0,0 +1,256 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	_ "time/tzdata" // the time zones of the maintenance windows have to be known in minimal images
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// podTemplateHashAnnotation holds the hash of the Vault Pod template applied to the StatefulSet
+	podTemplateHashAnnotation = "vault.banzaicloud.io/pod-template-hash"
+
+	// applyNowAnnotation on the Vault CR applies the staged changes outside of the maintenance windows
+	applyNowAnnotation = "vault.banzaicloud.io/apply-now"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// maintenanceWindow is a parsed MaintenanceWindow
+type maintenanceWindow struct {
+	days     map[time.Weekday]bool
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseMaintenanceWindow(w vaultv1alpha1.MaintenanceWindow) (*maintenanceWindow, error) {
+	var err error
+	window := &maintenanceWindow{location: time.UTC}
+
+	if window.start, err = parseClock(w.Start); err != nil {
+		return nil, err
+	}
+	if window.end, err = parseClock(w.End); err != nil {
+		return nil, err
+	}
+	if window.end <= window.start {
+		window.end += 24 * time.Hour
+	}
+
+	if w.TimeZone != "" {
+		if window.location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err)
+		}
+	}
+
+	if len(w.Days) > 0 {
+		window.days = map[time.Weekday]bool{}
+		for _, day := range w.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("invalid day %q, expected one of Mon, Tue, Wed, Thu, Fri, Sat, Sun", day)
+			}
+			window.days[weekday] = true
+		}
+	}
+
+	return window, nil
+}
+
+// opening returns when the window opens on the day of t
+func (w *maintenanceWindow) opening(t time.Time) time.Time {
+	year, month, day := t.In(w.location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, w.location).Add(w.start)
+}
+
+func (w *maintenanceWindow) opensOn(t time.Time) bool {
+	return w.days == nil || w.days[t.Weekday()]
+}
+
+// contains tells whether t is in the window, the window opened on the previous day may still be open
+func (w *maintenanceWindow) contains(t time.Time) bool {
+	for _, day := range []time.Time{t.In(w.location), t.In(w.location).AddDate(0, 0, -1)} {
+		opening := w.opening(day)
+		if w.opensOn(opening) && !t.Before(opening) && t.Before(opening.Add(w.end-w.start)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// next returns when the window opens next after t
+func (w *maintenanceWindow) next(t time.Time) time.Time {
+	for i := 0; i <= 7; i++ {
+		opening := w.opening(t.In(w.location).AddDate(0, 0, i))
+		if w.opensOn(opening) && opening.After(t) {
+			return opening
+		}
+	}
+
+	return time.Time{}
+}
+
+// inMaintenanceWindow tells whether t is in any of the maintenance windows, and when the next one opens
+func inMaintenanceWindow(windows []vaultv1alpha1.MaintenanceWindow, t time.Time) (bool, time.Time, error) {
+	open := false
+	var next time.Time
+
+	for _, w := range windows {
+		window, err := parseMaintenanceWindow(w)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+
+		if window.contains(t) {
+			open = true
+		}
+		if opening := window.next(t); !opening.IsZero() && (next.IsZero() || opening.Before(next)) {
+			next = opening
+		}
+	}
+
+	return open, next, nil
+}
+
+func podTemplateHash(template corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// gatePodTemplateChange stages the changes of the Vault Pod template outside of the maintenance windows,
+// by keeping the template of the current StatefulSet. It returns true if a change is staged.
+func (r *ReconcileVault) gatePodTemplateChange(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet) (bool, error) {
+	hash, err := podTemplateHash(statefulSet.Spec.Template)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash the pod template: %v", err)
+	}
+	statefulSet.Annotations[podTemplateHashAnnotation] = hash
+
+	if len(v.Spec.MaintenanceWindows) == 0 {
+		return false, r.setMaintenanceStatus(ctx, v, nil)
+	}
+
+	current := &appsv1.StatefulSet{}
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(statefulSet), current)
+	if apierrors.IsNotFound(err) {
+		return false, r.setMaintenanceStatus(ctx, v, nil)
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// StatefulSets created before the maintenance windows carry no hash, their template can't be compared
+	currentHash := current.Annotations[podTemplateHashAnnotation]
+	if currentHash == "" || currentHash == hash {
+		return false, r.setMaintenanceStatus(ctx, v, &vaultv1alpha1.MaintenanceStatus{})
+	}
+
+	now := time.Now()
+	open, next, err := inMaintenanceWindow(v.Spec.MaintenanceWindows, now)
+	if err != nil {
+		r.recorder.Event(v, corev1.EventTypeWarning, "InvalidMaintenanceWindow", err.Error())
+		return false, r.setMaintenanceStatus(ctx, v, &vaultv1alpha1.MaintenanceStatus{Message: err.Error()})
+	}
+
+	if _, forced := v.Annotations[applyNowAnnotation]; forced {
+		r.recorder.Event(v, corev1.EventTypeNormal, "StagedChangesApplied", "applying the staged pod template changes on request")
+
+		err := r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+			delete(v.Annotations, applyNowAnnotation)
+			v.Status.Maintenance = &vaultv1alpha1.MaintenanceStatus{}
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to remove the %s annotation: %v", applyNowAnnotation, err)
+		}
+		delete(v.Annotations, applyNowAnnotation)
+		v.Status.Maintenance = &vaultv1alpha1.MaintenanceStatus{}
+
+		return false, nil
+	}
+
+	if open {
+		if v.Status.Maintenance != nil && v.Status.Maintenance.PendingChanges {
+			r.recorder.Event(v, corev1.EventTypeNormal, "StagedChangesApplied", "applying the staged pod template changes in the maintenance window")
+		}
+		return false, r.setMaintenanceStatus(ctx, v, &vaultv1alpha1.MaintenanceStatus{})
+	}
+
+	statefulSet.Spec.Template = current.Spec.Template
+	statefulSet.Annotations[podTemplateHashAnnotation] = currentHash
+
+	status := &vaultv1alpha1.MaintenanceStatus{
+		PendingChanges: true,
+		PendingSince:   &metav1.Time{Time: now},
+		Message:        fmt.Sprintf("the pod template changes are staged until the next maintenance window, annotate the Vault with %s to apply them now", applyNowAnnotation),
+	}
+	if v.Status.Maintenance != nil && v.Status.Maintenance.PendingChanges && v.Status.Maintenance.PendingSince != nil {
+		status.PendingSince = v.Status.Maintenance.PendingSince
+	} else {
+		r.recorder.Event(v, corev1.EventTypeNormal, "ChangesStaged", status.Message)
+	}
+	if !next.IsZero() {
+		status.NextWindow = &metav1.Time{Time: next}
+	}
+
+	return true, r.setMaintenanceStatus(ctx, v, status)
+}
+
+func (r *ReconcileVault) setMaintenanceStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.MaintenanceStatus) error {
+	if reflect.DeepEqual(status, v.Status.Maintenance) || (v.Status.Maintenance == nil && reflect.DeepEqual(status, &vaultv1alpha1.MaintenanceStatus{})) {
+		return nil
+	}
+
+	v.Status.Maintenance = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Maintenance = status
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// 2026-10-17 is a Saturday
+	saturday := func(clock string) time.Time {
+		t, _ := time.Parse(time.RFC3339, "2026-10-17T"+clock+":00Z")
+		return t
+	}
+
+	tests := []struct {
+		name    string
+		windows []vaultv1alpha1.MaintenanceWindow
+		time    time.Time
+		open    bool
+		next    time.Time
+	}{
+		{
+			name:    "in daily window",
+			windows: []vaultv1alpha1.MaintenanceWindow{{Start: "02:00", End: "04:00"}},
+			time:    saturday("03:00"),
+			open:    true,
+			next:    saturday("02:00").AddDate(0, 0, 1),
+		},
+		{
+			name:    "before daily window",
+			windows: []vaultv1alpha1.MaintenanceWindow{{Start: "02:00", End: "04:00"}},
+			time:    saturday("01:00"),
+			next:    saturday("02:00"),
+		},
+		{
+			name:    "window over midnight",
+			windows: []vaultv1alpha1.MaintenanceWindow{{Days: []string{"Fri"}, Start: "22:00", End: "02:00"}},
+			time:    saturday("01:00"),
+			open:    true,
+			next:    saturday("22:00").AddDate(0, 0, 6),
+		},
+		{
+			name:    "other day",
+			windows: []vaultv1alpha1.MaintenanceWindow{{Days: []string{"Sun"}, Start: "02:00", End: "04:00"}},
+			time:    saturday("03:00"),
+			next:    saturday("02:00").AddDate(0, 0, 1),
+		},
+		{
+			name:    "time zone",
+			windows: []vaultv1alpha1.MaintenanceWindow{{Start: "02:00", End: "04:00", TimeZone: "Europe/Budapest"}},
+			time:    saturday("01:00"),
+			open:    true,
+			next:    saturday("00:00").AddDate(0, 0, 1),
+		},
+		{
+			name: "earliest of windows",
+			windows: []vaultv1alpha1.MaintenanceWindow{
+				{Days: []string{"Mon"}, Start: "02:00", End: "04:00"},
+				{Days: []string{"Sun"}, Start: "12:00", End: "13:00"},
+			},
+			time: saturday("03:00"),
+			next: saturday("12:00").AddDate(0, 0, 1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			open, next, err := inMaintenanceWindow(test.windows, test.time)
+			require.NoError(t, err)
+			assert.Equal(t, test.open, open)
+			assert.True(t, test.next.Equal(next), "next window %s, expected %s", next, test.next)
+		})
+	}
+
+	_, _, err := inMaintenanceWindow([]vaultv1alpha1.MaintenanceWindow{{Days: []string{"Someday"}, Start: "02:00", End: "04:00"}}, saturday("03:00"))
+	assert.Error(t, err)
+}
+
+func TestGatePodTemplateChange(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	closedWindow := vaultv1alpha1.MaintenanceWindow{
+		Start: now.Add(2 * time.Hour).Format("15:04"),
+		End:   now.Add(3 * time.Hour).Format("15:04"),
+	}
+	openWindow := vaultv1alpha1.MaintenanceWindow{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}
+
+	currentTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vault", Image: "hashicorp/vault:1.19.0"}}},
+	}
+	currentHash, err := podTemplateHash(currentTemplate)
+	require.NoError(t, err)
+
+	newStatefulSet := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default", Annotations: map[string]string{}},
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vault", Image: "hashicorp/vault:1.20.0"}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		windows  []vaultv1alpha1.MaintenanceWindow
+		applyNow bool
+		staged   bool
+	}{
+		{name: "no windows"},
+		{name: "closed window", windows: []vaultv1alpha1.MaintenanceWindow{closedWindow}, staged: true},
+		{name: "open window", windows: []vaultv1alpha1.MaintenanceWindow{closedWindow, openWindow}},
+		{name: "apply now", windows: []vaultv1alpha1.MaintenanceWindow{closedWindow}, applyNow: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &vaultv1alpha1.Vault{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default", Annotations: map[string]string{}},
+				Spec:       vaultv1alpha1.VaultSpec{Size: 3, MaintenanceWindows: test.windows},
+			}
+			if test.applyNow {
+				v.Annotations[applyNowAnnotation] = "true"
+			}
+			current := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vault", Namespace: "default",
+					Annotations: map[string]string{podTemplateHashAnnotation: currentHash},
+				},
+				Spec: appsv1.StatefulSetSpec{Template: currentTemplate},
+			}
+
+			r, c := newTestReconciler(v, current)
+
+			statefulSet := newStatefulSet()
+			staged, err := r.gatePodTemplateChange(ctx, v, statefulSet)
+			require.NoError(t, err)
+			assert.Equal(t, test.staged, staged)
+
+			updated := &vaultv1alpha1.Vault{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), updated))
+
+			if test.staged {
+				assert.Equal(t, currentTemplate, statefulSet.Spec.Template)
+				assert.Equal(t, currentHash, statefulSet.Annotations[podTemplateHashAnnotation])
+				require.NotNil(t, updated.Status.Maintenance)
+				assert.True(t, updated.Status.Maintenance.PendingChanges)
+				assert.NotNil(t, updated.Status.Maintenance.NextWindow)
+			} else {
+				assert.Equal(t, "hashicorp/vault:1.20.0", statefulSet.Spec.Template.Spec.Containers[0].Image)
+				assert.NotEqual(t, currentHash, statefulSet.Annotations[podTemplateHashAnnotation])
+				assert.True(t, updated.Status.Maintenance == nil || !updated.Status.Maintenance.PendingChanges)
+			}
+			assert.NotContains(t, updated.Annotations, applyNowAnnotation)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unsealSidecarName is the name of the bank-vaults unseal sidecar container in the Vault Pods
+const unsealSidecarName = "bank-vaults"
+
+// withoutUnsealSidecar removes the bank-vaults unseal sidecar, if the Vault Pods are unsealed by the operator,
+// or by the key holders with unseal key escrow
+func withoutUnsealSidecar(v *vaultv1alpha1.Vault, containers []corev1.Container) []corev1.Container {
+	if !v.Spec.UnsealConfig.IsOperatorUnseal(v) && v.Spec.UnsealConfig.Escrow == nil {
+		return containers
+	}
+
+	var result []corev1.Container
+	for _, container := range containers {
+		if container.Name != unsealSidecarName {
+			result = append(result, container)
+		}
+	}
+	return result
+}
+
+// reconcileOperatorUnseal initializes Vault through the first Pod, joins the rest of the Pods to the raft cluster,
+// and unseals the sealed Pods with the keys stored in the Kubernetes Secret. It returns true until all Pods are unsealed.
+func (r *ReconcileVault) reconcileOperatorUnseal(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	status := &vaultv1alpha1.OperatorUnsealStatus{}
+	if v.Status.Unseal != nil {
+		status.Initialized = v.Status.Unseal.Initialized
+	}
+
+	namespace, name, _ := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	prefix := sealKeyPrefix(v.Spec.GetSealType())
+
+	secret := &corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+	keys := storedKeys(secret.Data, prefix)
+
+	pending := false
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		vaultClient, err := NewVaultClientForPod(ctx, r.client, v, podName)
+		if err != nil {
+			return false, err
+		}
+
+		sealStatus, err := vaultClient.Sys().SealStatus()
+		if err != nil {
+			// Not reachable yet, will be checked again in the next reconcile
+			pending = true
+			continue
+		}
+
+		if !sealStatus.Initialized {
+			pending = true
+
+			// The first Pod initializes the cluster, the rest of them join it once it is initialized,
+			// with a shared storage backend they are initialized through the first Pod
+			if v.Spec.IsRaftStorage() && (i > 0 || v.Spec.IsRaftBootstrapFollower()) {
+				if !status.Initialized && !v.Spec.IsRaftBootstrapFollower() {
+					continue
+				}
+				if err := r.joinRaftCluster(ctx, v, vaultClient); err != nil {
+					status.Message = fmt.Sprintf("failed to join %s to the raft cluster: %v", podName, err)
+					continue
+				}
+				r.recorder.Eventf(v, corev1.EventTypeNormal, "RaftJoined", "%s joined the raft cluster at %s", podName, raftLeaderAPIAddr(v))
+			} else if i == 0 {
+				if len(keys) > 0 {
+					status.Message = fmt.Sprintf("secret %s/%s already holds %s keys of another initialization, delete them to initialize Vault again", namespace, name, prefix)
+					continue
+				}
+				keys, err = r.initializeVault(ctx, v, vaultClient, namespace, name)
+				if err != nil {
+					status.Message = err.Error()
+					continue
+				}
+				status.Initialized = true
+			} else {
+				continue
+			}
+
+			// With Shamir the initialized and joined Pods are unsealed right away, which also completes the join
+			if v.Spec.IsAutoUnseal() || len(keys) == 0 {
+				continue
+			}
+			sealStatus.Sealed = true
+		}
+		status.Initialized = true
+
+		if !sealStatus.Sealed {
+			continue
+		}
+
+		// With auto-unseal Vault unseals itself once it is initialized
+		if v.Spec.IsAutoUnseal() {
+			pending = true
+			continue
+		}
+
+		if len(keys) == 0 {
+			status.SealedPods = append(status.SealedPods, podName)
+			status.Message = fmt.Sprintf("secret %s/%s holds no %s keys", namespace, name, prefix)
+			continue
+		}
+
+		if err := unsealWithKeys(vaultClient, keys, false); err != nil {
+			status.SealedPods = append(status.SealedPods, podName)
+			status.Message = fmt.Sprintf("failed to unseal %s: %v", podName, err)
+			pending = true
+			continue
+		}
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "Unsealed", "%s unsealed by the operator", podName)
+	}
+
+	return pending, r.setOperatorUnsealStatus(ctx, v, status)
+}
+
+// initializeVault initializes Vault, and stores the unseal (or with auto-unseal the recovery) keys
+// and the root token in the Kubernetes Secret, the same way the bank-vaults sidecar does
+func (r *ReconcileVault) initializeVault(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, namespace, name string) ([]string, error) {
+	options := v.Spec.UnsealConfig.Options
+
+	request := &api.InitRequest{}
+	if v.Spec.IsAutoUnseal() {
+		request.RecoveryShares = options.GetSecretShares()
+		request.RecoveryThreshold = options.GetSecretThreshold()
+	} else {
+		request.SecretShares = options.GetSecretShares()
+		request.SecretThreshold = options.GetSecretThreshold()
+	}
+
+	response, err := vaultClient.Sys().Init(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize vault: %v", err)
+	}
+
+	keys := response.Keys
+	if v.Spec.IsAutoUnseal() {
+		keys = response.RecoveryKeys
+	}
+
+	data := withStoredKeys(nil, sealKeyPrefix(v.Spec.GetSealType()), keys)
+	if options.StoreRootToken == nil || *options.StoreRootToken {
+		data["vault-root"] = []byte(response.RootToken)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    v.LabelsForVault(),
+		},
+		Data: data,
+	}
+
+	// The keys can't be retrieved again, so transient errors are retried here instead of the next reconcile
+	err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		return createOrUpdateObjectWithClient(ctx, r.nonNamespacedClient, secret.DeepCopy())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store the keys in secret %s/%s: %v", namespace, name, err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "Initialized",
+		"vault initialized with %d keys, threshold %d, stored in secret %s/%s", len(keys), options.GetSecretThreshold(), namespace, name)
+
+	return keys, nil
+}
+
+// joinRaftCluster joins the Vault Pod to the raft cluster of the leader, the join is retried by Vault in the background
+func (r *ReconcileVault) joinRaftCluster(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client) error {
+	request := &api.RaftJoinRequest{
+		LeaderAPIAddr: raftLeaderAPIAddr(v),
+		Retry:         true,
+	}
+
+	// The leader of a federation runs in another cluster, with a CA of its own
+	caSecretName := ""
+	if f, err := r.federationForVault(ctx, v); err != nil {
+		return err
+	} else if f != nil && v.Spec.IsRaftBootstrapFollower() {
+		caSecretName = f.Status.LeaderCASecretName
+	} else if !v.Spec.IsTLSDisabled() {
+		caSecretName = tlsSecretName(v)
+	}
+
+	if caSecretName != "" {
+		caSecret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: caSecretName}, caSecret); err != nil {
+			return fmt.Errorf("failed to get the ca of the raft leader: %v", err)
+		}
+		request.LeaderCACert = string(caSecret.Data["ca.crt"])
+	}
+
+	_, err := vaultClient.Sys().RaftJoin(request)
+	return err
+}
+
+func (r *ReconcileVault) setOperatorUnsealStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.OperatorUnsealStatus) error {
+	if reflect.DeepEqual(status, v.Status.Unseal) {
+		return nil
+	}
+
+	if status.Message != "" && (v.Status.Unseal == nil || v.Status.Unseal.Message != status.Message) {
+		r.recorder.Event(v, corev1.EventTypeWarning, "UnsealFailed", status.Message)
+	}
+
+	v.Status.Unseal = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Unseal = status
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperatorUnsealSidecar(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				OperatorUnseal: true,
+			},
+		},
+	}
+
+	containerNames := func() []string {
+		statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+		require.NoError(t, err)
+		var names []string
+		for _, container := range statefulSet.Spec.Template.Spec.Containers {
+			names = append(names, container.Name)
+		}
+		return names
+	}
+
+	assert.True(t, v.Spec.UnsealConfig.IsOperatorUnseal(v))
+	assert.NotContains(t, containerNames(), "bank-vaults")
+
+	// Other backends keep the sidecar
+	v.Spec.UnsealConfig.AWS = &vaultv1alpha1.AWSUnsealConfig{KMSKeyID: "key"}
+	assert.False(t, v.Spec.UnsealConfig.IsOperatorUnseal(v))
+	assert.Contains(t, containerNames(), "bank-vaults")
+
+	v.Spec.UnsealConfig.AWS = nil
+	v.Spec.UnsealConfig.OperatorUnseal = false
+	assert.Contains(t, containerNames(), "bank-vaults")
+}
+
+func TestRaftLeaderAPIAddr(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+	}
+	assert.Equal(t, "https://vault:8200", raftLeaderAPIAddr(v))
+
+	v.Spec.RaftLeaderAddress = "vault.primary"
+	v.Spec.RaftLeaderApiSchemeOverride = "http"
+	assert.Equal(t, "http://vault.primary:8200", raftLeaderAPIAddr(v))
+}
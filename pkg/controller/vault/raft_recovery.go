@@ -0,0 +1,273 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// raftPeer is an entry of the raft/peers.json file
+// See: https://developer.hashicorp.com/vault/docs/concepts/integrated-storage#manual-recovery-using-peers-json
+type raftPeer struct {
+	ID       string `json:"id"`
+	Address  string `json:"address"`
+	NonVoter bool   `json:"non_voter"`
+}
+
+func raftRecoveryConfigMapName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-raft-recovery"
+}
+
+// configMapForRaftRecovery returns the peers.json of the recovered cluster, and the list of survivors
+// for the recovery init container to decide whether it should write the peers.json or not
+func configMapForRaftRecovery(v *vaultv1alpha1.Vault, survivors []string) (*corev1.ConfigMap, error) {
+	var peers []raftPeer
+	for _, podName := range survivors {
+		peers = append(peers, raftPeer{
+			ID:      v.Spec.RaftRecovery.GetNodeID(podName),
+			Address: v.GetRaftAddress(podName),
+		})
+	}
+
+	peersJSON, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      raftRecoveryConfigMapName(v),
+			Namespace: v.Namespace,
+			Labels:    withVaultLabels(v, v.LabelsForVault()),
+		},
+		Data: map[string]string{
+			"peers.json": string(peersJSON),
+			"survivors":  strings.Join(survivors, "\n") + "\n",
+		},
+	}, nil
+}
+
+func withRaftRecoveryVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if v.Spec.RaftRecovery != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-raft-recovery",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: raftRecoveryConfigMapName(v)},
+					Optional:             ptr.To(true),
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// withRaftRecoveryInitContainer adds an init container which writes the peers.json file
+// into the raft directory, but only on the surviving Vault Pods
+func withRaftRecoveryInitContainer(v *vaultv1alpha1.Vault, containers []corev1.Container) []corev1.Container {
+	if v.Spec.RaftRecovery == nil {
+		return containers
+	}
+
+	raftPath := v.Spec.GetRaftPath()
+	script := fmt.Sprintf("if grep -qx \"$POD_NAME\" /vault/raft-recovery/survivors; then "+
+		"mkdir -p %[1]s/raft && cp /vault/raft-recovery/peers.json %[1]s/raft/peers.json && echo peers.json written; fi", raftPath)
+
+	return append(containers, corev1.Container{
+		Image:           v.Spec.GetVaultImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            "raft-recovery",
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
+		},
+		// The raft data is owned by the vault user of the official Vault image
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  ptr.To(int64(100)),
+			RunAsGroup: ptr.To(int64(1000)),
+		},
+		VolumeMounts: withVaultVolumeMounts(v, []corev1.VolumeMount{
+			{
+				Name:      "vault-raft-recovery",
+				MountPath: "/vault/raft-recovery",
+			},
+		}),
+		Resources: getVaultResource(v),
+	})
+}
+
+// reconcileRaftRecovery drives a raft lost quorum recovery one step forward,
+// it returns true if the recovery is in progress and should be checked again
+func (r *ReconcileVault) reconcileRaftRecovery(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	if !v.Spec.IsRaftStorage() {
+		return false, r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryFailed, nil, "raft recovery requires raft storage")
+	}
+
+	// Failed recoveries are retried, since the missing survivors might show up
+	status := v.Status.RaftRecovery
+	if status == nil || status.Phase == vaultv1alpha1.RaftRecoveryCompleted || status.Phase == vaultv1alpha1.RaftRecoveryFailed {
+		return true, r.prepareRaftRecovery(ctx, v)
+	}
+
+	switch status.Phase {
+	case vaultv1alpha1.RaftRecoveryPeersWritten:
+		// The StatefulSet already contains the recovery init container at this point,
+		// the rolling update can't progress without quorum, so restart the survivors directly
+		for _, podName := range status.Survivors {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: v.Namespace}}
+			if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to restart pod %s: %v", podName, err)
+			}
+		}
+
+		return true, r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryRestarting, status.Survivors,
+			fmt.Sprintf("restarted surviving pods: %s", strings.Join(status.Survivors, ", ")))
+
+	case vaultv1alpha1.RaftRecoveryRestarting:
+		leader := ""
+		for _, podName := range status.Survivors {
+			vaultClient, err := newInsecureVaultClientForPod(v, podName)
+			if err != nil {
+				return false, err
+			}
+
+			health, err := vaultClient.Sys().Health()
+			if err == nil && health.Initialized && !health.Sealed && !health.Standby {
+				leader = podName
+				break
+			}
+		}
+
+		if leader == "" {
+			log.V(1).Info("waiting for the recovered raft cluster to elect a leader", "vault", v.Name)
+			return true, nil
+		}
+
+		err := r.client.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: raftRecoveryConfigMapName(v), Namespace: v.Namespace}})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete raft recovery configmap: %v", err)
+		}
+
+		// The recovery init container has to be removed from the StatefulSet as soon as possible,
+		// so the peers.json file is not written again on the next restart of the survivors
+		err = r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+			v.Spec.RaftRecovery = nil
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to clear raft recovery: %v", err)
+		}
+
+		return false, r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryCompleted, status.Survivors,
+			fmt.Sprintf("raft cluster recovered, %s is the active node", leader))
+	}
+
+	return false, nil
+}
+
+// prepareRaftRecovery selects the survivors and writes the peers.json for them
+func (r *ReconcileVault) prepareRaftRecovery(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	pods := podList()
+	listOps := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(v.LabelsForVault()),
+		Namespace:     v.Namespace,
+	}
+	if err := r.client.List(ctx, pods, listOps); err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	survivors := append([]string{}, v.Spec.RaftRecovery.Survivors...)
+	if len(survivors) == 0 {
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodRunning {
+				survivors = append(survivors, pod.Name)
+			}
+		}
+	} else {
+		podNames := getPodNames(pods.Items)
+		for _, survivor := range survivors {
+			if i := sort.SearchStrings(podNames, survivor); i == len(podNames) || podNames[i] != survivor {
+				return r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryFailed, survivors,
+					fmt.Sprintf("surviving pod %s doesn't exist", survivor))
+			}
+		}
+	}
+
+	if len(survivors) == 0 {
+		return r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryFailed, nil, "no surviving pods found")
+	}
+
+	sort.Strings(survivors)
+
+	cm, err := configMapForRaftRecovery(v, survivors)
+	if err != nil {
+		return fmt.Errorf("failed to fabricate raft recovery configmap: %v", err)
+	}
+
+	if err := controllerutil.SetControllerReference(v, cm, r.scheme); err != nil {
+		return err
+	}
+
+	if err := r.createOrUpdateObject(ctx, cm); err != nil {
+		return fmt.Errorf("failed to create/update raft recovery configmap: %v", err)
+	}
+
+	return r.setRaftRecoveryStatus(ctx, v, vaultv1alpha1.RaftRecoveryPeersWritten, survivors,
+		fmt.Sprintf("peers.json prepared for surviving pods: %s", strings.Join(survivors, ", ")))
+}
+
+// setRaftRecoveryStatus records the recovery phase in the status and as an Event
+func (r *ReconcileVault) setRaftRecoveryStatus(ctx context.Context, v *vaultv1alpha1.Vault, phase vaultv1alpha1.RaftRecoveryPhase, survivors []string, message string) error {
+	if current := v.Status.RaftRecovery; current != nil && current.Phase == phase && current.Message == message {
+		return nil
+	}
+
+	eventType := corev1.EventTypeNormal
+	if phase == vaultv1alpha1.RaftRecoveryFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Event(v, eventType, "RaftRecovery"+string(phase), message)
+
+	status := &vaultv1alpha1.RaftRecoveryStatus{
+		Phase:              phase,
+		Survivors:          survivors,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	v.Status.RaftRecovery = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.RaftRecovery = status
+	})
+}
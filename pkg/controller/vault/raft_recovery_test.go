@@ -0,0 +1,64 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigMapForRaftRecovery(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault",
+			Namespace: "default",
+		},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 3,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "cluster_addr": "https://${.Env.POD_NAME}:8201"}`),
+			},
+			RaftRecovery: &vaultv1alpha1.RaftRecovery{
+				NodeIDs: map[string]string{"vault-2": "a9e5f3b0"},
+			},
+		},
+	}
+
+	cm, err := configMapForRaftRecovery(v, []string{"vault-0", "vault-2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "vault-raft-recovery", cm.Name)
+	assert.Equal(t, "vault-0\nvault-2\n", cm.Data["survivors"])
+	assert.JSONEq(t, `[
+		{"id": "vault-0", "address": "vault-0:8201", "non_voter": false},
+		{"id": "a9e5f3b0", "address": "vault-2:8201", "non_voter": false}
+	]`, cm.Data["peers.json"])
+
+	initContainers := withRaftRecoveryInitContainer(v, nil)
+	require.Len(t, initContainers, 1)
+	assert.Contains(t, initContainers[0].Command[2], "/vault/file/raft/peers.json")
+
+	// The script runs in the Vault image, which ships a shell
+	assert.Equal(t, v.Spec.GetVaultImage(), initContainers[0].Image)
+	assert.Equal(t, "/bin/sh", initContainers[0].Command[0])
+
+	v.Spec.Image = "hashicorp/vault:1.14.8"
+	assert.Equal(t, "hashicorp/vault:1.14.8", withRaftRecoveryInitContainer(v, nil)[0].Image)
+}
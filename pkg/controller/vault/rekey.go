@@ -0,0 +1,344 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// unsealKeyPrefix is the prefix of the unseal keys stored by bank-vaults in a Kubernetes Secret
+	unsealKeyPrefix = "vault-unseal-"
+
+	// recoveryKeyPrefix is the prefix of the recovery keys stored by bank-vaults with auto-unseal
+	recoveryKeyPrefix = "vault-recovery-"
+)
+
+// rekeyStagingSecretName is the Secret holding the new unseal keys until the rekey is verified
+func rekeyStagingSecretName(name string) string {
+	return name + "-rekey"
+}
+
+// unsealKeys returns the unseal keys stored in the Secret data, ordered by their index
+func unsealKeys(data map[string][]byte) []string {
+	return storedKeys(data, unsealKeyPrefix)
+}
+
+// storedKeys returns the keys with the given prefix stored in the Secret data, ordered by their index
+func storedKeys(data map[string][]byte, prefix string) []string {
+	var keys []string
+	for i := 0; ; i++ {
+		key, ok := data[fmt.Sprintf("%s%d", prefix, i)]
+		if !ok {
+			return keys
+		}
+		keys = append(keys, string(key))
+	}
+}
+
+// withUnsealKeys returns a copy of the Secret data with the unseal keys replaced, other keys (e.g. the root token) are kept
+func withUnsealKeys(data map[string][]byte, keys []string) map[string][]byte {
+	return withStoredKeys(data, unsealKeyPrefix, keys)
+}
+
+// withStoredKeys returns a copy of the Secret data with the keys with the given prefix replaced
+func withStoredKeys(data map[string][]byte, prefix string, keys []string) map[string][]byte {
+	result := map[string][]byte{}
+	for key, value := range data {
+		if !strings.HasPrefix(key, prefix) {
+			result[key] = value
+		}
+	}
+	for i, key := range keys {
+		result[fmt.Sprintf("%s%d", prefix, i)] = []byte(key)
+	}
+	return result
+}
+
+// reconcileRekey rekeys Vault with the stored unseal keys, when the secret shares or threshold
+// in the unseal options differ from the ones Vault has been initialized (or last rekeyed) with
+func (r *ReconcileVault) reconcileRekey(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	options := v.Spec.UnsealConfig.Options
+	status := &vaultv1alpha1.RekeyStatus{
+		SecretShares:    options.GetSecretShares(),
+		SecretThreshold: options.GetSecretThreshold(),
+	}
+	if old := v.Status.Rekey; old != nil && old.SecretShares == status.SecretShares && old.SecretThreshold == status.SecretThreshold {
+		status.Attempts = old.Attempts
+	}
+
+	namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	if !ok || v.Spec.UnsealConfig.HSM != nil || v.Spec.UnsealConfig.Escrow != nil || v.Spec.IsAutoUnseal() {
+		if v.Status.Rekey == nil {
+			return nil
+		}
+		status.Phase = vaultv1alpha1.RekeyFailed
+		status.Message = "rekeying is only supported if the unseal keys are stored in a Kubernetes Secret"
+		return r.setRekeyStatus(ctx, v, status)
+	}
+
+	if status.SecretThreshold < 1 || status.SecretThreshold > status.SecretShares {
+		status.Phase = vaultv1alpha1.RekeyFailed
+		status.Message = fmt.Sprintf("the threshold %d is not valid for %d shares", status.SecretThreshold, status.SecretShares)
+		return r.setRekeyStatus(ctx, v, status)
+	}
+
+	vaultClient, err := ActiveVaultClient(ctx, r.client, v)
+	if err != nil || vaultClient == nil {
+		// No active node to talk to, will be checked again in the next reconcile
+		return err
+	}
+
+	sealStatus, err := vaultClient.Sys().SealStatus()
+	if err != nil {
+		return nil
+	}
+
+	staging := &corev1.Secret{}
+	err = r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: rekeyStagingSecretName(name)}, staging)
+	if apierrors.IsNotFound(err) {
+		staging = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get rekey staging secret: %v", err)
+	}
+
+	if sealStatus.N == status.SecretShares && sealStatus.T == status.SecretThreshold {
+		// The new keys are active in Vault, but the operator stopped before storing them
+		if staging != nil {
+			return r.finishRekey(ctx, v, namespace, name, unsealKeys(staging.Data), status)
+		}
+		return nil
+	}
+
+	if staging != nil {
+		verification, err := vaultClient.Sys().RekeyVerificationStatus()
+		if err == nil && verification.Started {
+			return r.verifyRekey(ctx, v, vaultClient, namespace, name, unsealKeys(staging.Data), verification.Nonce, status)
+		}
+
+		// The staged keys are kept until Vault tells for sure, they are the only copy of the new keys
+		if !rekeyVerificationCanceled(verification, err) {
+			return nil
+		}
+
+		// The ceremony behind the staged keys has been canceled, they will never become active
+		if err := r.nonNamespacedClient.Delete(ctx, staging); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rekey staging secret: %v", err)
+		}
+	}
+
+	primary := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, primary); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	oldKeys := unsealKeys(primary.Data)
+	if len(oldKeys) < sealStatus.T {
+		status.Phase = vaultv1alpha1.RekeyFailed
+		status.Message = fmt.Sprintf("%d unseal keys are stored, but %d are required to rekey", len(oldKeys), sealStatus.T)
+		return r.setRekeyStatus(ctx, v, status)
+	}
+
+	// Starting a ceremony cancels the one in progress, so a failed rekey is retried with a backoff
+	if old := v.Status.Rekey; old != nil && old.Phase == vaultv1alpha1.RekeyFailed && status.Attempts > 0 &&
+		time.Since(old.LastTransitionTime.Time) < rekeyRetryBackoff(status.Attempts) {
+		return nil
+	}
+
+	// A rekey started by someone else can't be finished with our keys, since its nonce is unknown
+	if rekeyStatus, err := vaultClient.Sys().RekeyStatus(); err == nil && rekeyStatus.Started {
+		if err := vaultClient.Sys().RekeyCancel(); err != nil {
+			return fmt.Errorf("failed to cancel rekey in progress: %v", err)
+		}
+	}
+
+	initResponse, err := vaultClient.Sys().RekeyInit(&api.RekeyInitRequest{
+		SecretShares:        status.SecretShares,
+		SecretThreshold:     status.SecretThreshold,
+		RequireVerification: true,
+	})
+	if err != nil {
+		return r.failRekey(ctx, v, status, fmt.Sprintf("failed to start rekey: %v", err))
+	}
+
+	status.Phase = vaultv1alpha1.RekeyStarted
+	status.Nonce = initResponse.Nonce
+	status.Required = initResponse.Required
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RekeyStarted", "rekeying from %d/%d to %d/%d unseal key shares",
+		sealStatus.T, sealStatus.N, status.SecretThreshold, status.SecretShares)
+	if err := r.setRekeyStatus(ctx, v, status); err != nil {
+		return err
+	}
+
+	var updateResponse *api.RekeyUpdateResponse
+	for _, key := range oldKeys {
+		updateResponse, err = vaultClient.Sys().RekeyUpdate(key, status.Nonce)
+		if err != nil {
+			_ = vaultClient.Sys().RekeyCancel()
+			return r.failRekey(ctx, v, status, fmt.Sprintf("failed to submit unseal key: %v", err))
+		}
+		status.Progress++
+		if updateResponse.Complete {
+			break
+		}
+	}
+
+	if updateResponse == nil || !updateResponse.Complete {
+		_ = vaultClient.Sys().RekeyCancel()
+		return r.failRekey(ctx, v, status, "the stored unseal keys couldn't complete the rekey")
+	}
+
+	// The new keys are not active until verified, so they are staged first, to survive an operator restart
+	staging = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rekeyStagingSecretName(name),
+			Namespace: namespace,
+			Labels:    withVaultLabels(v, v.LabelsForVault()),
+		},
+		Data: withUnsealKeys(nil, updateResponse.Keys),
+	}
+	err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		return r.createOrUpdateObject(ctx, staging.DeepCopy())
+	})
+	if err != nil {
+		_ = vaultClient.Sys().RekeyCancel()
+		return r.failRekey(ctx, v, status, fmt.Sprintf("failed to stage the new unseal keys: %v", err))
+	}
+
+	return r.verifyRekey(ctx, v, vaultClient, namespace, name, updateResponse.Keys, updateResponse.VerificationNonce, status)
+}
+
+// verifyRekey submits the new unseal keys to Vault, which activates them
+func (r *ReconcileVault) verifyRekey(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, namespace, name string, keys []string, nonce string, status *vaultv1alpha1.RekeyStatus) error {
+	status.Phase = vaultv1alpha1.RekeyVerifying
+	status.Nonce = nonce
+	status.Progress = 0
+	status.Required = status.SecretThreshold
+	if err := r.setRekeyStatus(ctx, v, status); err != nil {
+		return err
+	}
+
+	complete := false
+	for _, key := range keys {
+		response, err := vaultClient.Sys().RekeyVerificationUpdate(key, nonce)
+		if err != nil {
+			return r.failRekey(ctx, v, status, fmt.Sprintf("failed to verify the new unseal keys: %v", err))
+		}
+		status.Progress++
+		if response.Complete {
+			complete = true
+			break
+		}
+	}
+
+	if !complete {
+		return r.failRekey(ctx, v, status, "the staged unseal keys couldn't complete the verification")
+	}
+
+	return r.finishRekey(ctx, v, namespace, name, keys, status)
+}
+
+// finishRekey replaces the unseal keys in the backend with a single update, and removes the staged keys
+func (r *ReconcileVault) finishRekey(ctx context.Context, v *vaultv1alpha1.Vault, namespace, name string, keys []string, status *vaultv1alpha1.RekeyStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		primary := &corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, primary); err != nil {
+			return err
+		}
+		primary.Data = withUnsealKeys(primary.Data, keys)
+		return r.nonNamespacedClient.Update(ctx, primary)
+	})
+	if err != nil {
+		// The staged keys are kept, the next reconcile tries again
+		return fmt.Errorf("failed to store the new unseal keys: %v", err)
+	}
+
+	staging := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: rekeyStagingSecretName(name)}}
+	if err := r.nonNamespacedClient.Delete(ctx, staging); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rekey staging secret: %v", err)
+	}
+
+	status.Phase = vaultv1alpha1.RekeyCompleted
+	status.Message = ""
+	status.Attempts = 0
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RekeyCompleted", "Vault rekeyed to %d/%d unseal key shares, stored in %s/%s",
+		status.SecretThreshold, status.SecretShares, namespace, name)
+
+	return r.setRekeyStatus(ctx, v, status)
+}
+
+// failRekey reports a failed rekey ceremony, which is retried after a backoff
+func (r *ReconcileVault) failRekey(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.RekeyStatus, message string) error {
+	status.Phase = vaultv1alpha1.RekeyFailed
+	status.Message = message
+	status.Attempts++
+	return r.setRekeyStatus(ctx, v, status)
+}
+
+// rekeyRetryBackoff returns how long to wait before starting a new rekey ceremony after the given failed attempts
+func rekeyRetryBackoff(attempts int) time.Duration {
+	backoff := time.Minute
+	for i := 1; i < attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	return min(backoff, time.Hour)
+}
+
+// rekeyVerificationCanceled checks if Vault has definitely no rekey verification in progress,
+// a failed request (e.g. an unreachable Vault) tells nothing
+func rekeyVerificationCanceled(verification *api.RekeyVerificationStatusResponse, err error) bool {
+	if err != nil {
+		var responseError *api.ResponseError
+		return errors.As(err, &responseError) && responseError.StatusCode == http.StatusBadRequest
+	}
+	return !verification.Started
+}
+
+func (r *ReconcileVault) setRekeyStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.RekeyStatus) error {
+	old := v.Status.Rekey
+	if old != nil && old.Phase == status.Phase && old.Message == status.Message && old.Attempts == status.Attempts {
+		status.LastTransitionTime = old.LastTransitionTime
+		if *old == *status {
+			return nil
+		}
+	} else {
+		status.LastTransitionTime = metav1.Now()
+		if status.Phase == vaultv1alpha1.RekeyFailed {
+			r.recorder.Event(v, corev1.EventTypeWarning, "RekeyFailed", status.Message)
+		}
+	}
+
+	v.Status.Rekey = status.DeepCopy()
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Rekey = status.DeepCopy()
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFinishRekey(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+	}
+	primary := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data: map[string][]byte{
+			"vault-root":     []byte("root"),
+			"vault-unseal-0": []byte("old-0"),
+			"vault-unseal-1": []byte("old-1"),
+			"vault-unseal-2": []byte("old-2"),
+		},
+	}
+	staging := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: rekeyStagingSecretName("vault-unseal-keys"), Namespace: "default"},
+		Data:       withUnsealKeys(nil, []string{"new-0", "new-1"}),
+	}
+
+	assert.Equal(t, []string{"old-0", "old-1", "old-2"}, unsealKeys(primary.Data))
+
+	reconciler, c := newTestReconciler(v, primary, staging)
+
+	status := &vaultv1alpha1.RekeyStatus{SecretShares: 2, SecretThreshold: 1}
+	require.NoError(t, reconciler.finishRekey(ctx, v, "default", "vault-unseal-keys", unsealKeys(staging.Data), status))
+
+	// The unseal keys are replaced, the root token is kept
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(primary), primary))
+	assert.Equal(t, map[string][]byte{
+		"vault-root":     []byte("root"),
+		"vault-unseal-0": []byte("new-0"),
+		"vault-unseal-1": []byte("new-1"),
+	}, primary.Data)
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(staging), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NotNil(t, v.Status.Rekey)
+	assert.Equal(t, vaultv1alpha1.RekeyCompleted, v.Status.Rekey.Phase)
+}
+
+func TestRekeyRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, rekeyRetryBackoff(1))
+	assert.Equal(t, 4*time.Minute, rekeyRetryBackoff(3))
+	assert.Equal(t, time.Hour, rekeyRetryBackoff(10))
+}
+
+func TestRekeyVerificationCanceled(t *testing.T) {
+	// Only a definite answer of Vault lets the staged keys go
+	assert.True(t, rekeyVerificationCanceled(&api.RekeyVerificationStatusResponse{}, nil))
+	assert.True(t, rekeyVerificationCanceled(nil, &api.ResponseError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, rekeyVerificationCanceled(&api.RekeyVerificationStatusResponse{Started: true}, nil))
+	assert.False(t, rekeyVerificationCanceled(nil, &api.ResponseError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, rekeyVerificationCanceled(nil, errors.New("connection refused")))
+}
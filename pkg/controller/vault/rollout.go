@@ -0,0 +1,237 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isRollingOut checks if a canary rollout (or its rollback) is in progress
+func isRollingOut(v *vaultv1alpha1.Vault) bool {
+	if v.Status.Rollout == nil {
+		return false
+	}
+	switch v.Status.Rollout.Phase {
+	case vaultv1alpha1.RolloutCanary, vaultv1alpha1.RolloutProgressing, vaultv1alpha1.RolloutRollingBack:
+		return true
+	}
+	return false
+}
+
+// rolloutResetAnnotation on the Vault resource rolls out a rolled back config change again, starting with the canaries
+const rolloutResetAnnotation = "vault.banzaicloud.com/reset-rollout"
+
+// rolloutPartition returns the partition of the Vault StatefulSet, and starts a canary rollout if the Vault config
+// has changed. It has to run before the StatefulSet picks up the new config.
+func (r *ReconcileVault) rolloutPartition(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet, configSum string) (int32, error) {
+	if status := v.Status.Rollout; status != nil && status.ConfigSum == configSum {
+		switch status.Phase {
+		case vaultv1alpha1.RolloutCanary, vaultv1alpha1.RolloutProgressing, vaultv1alpha1.RolloutRollingBack:
+			return status.Partition, nil
+		case vaultv1alpha1.RolloutRolledBack:
+			if _, ok := v.Annotations[rolloutResetAnnotation]; ok {
+				return r.startRollout(ctx, v, configSum)
+			}
+			// The failed config is not rolled out again, and it holds back every other change of the Pod template
+			return v.Spec.Size, r.reportBlockedRollout(ctx, v, statefulSet)
+		}
+	}
+
+	current := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, current)
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	currentSum := current.Spec.Template.Annotations["vault.banzaicloud.io/vault-config"]
+	if !isRollingOut(v) && (currentSum == "" || currentSum == configSum) {
+		return 0, nil
+	}
+
+	return r.startRollout(ctx, v, configSum)
+}
+
+// startRollout starts a canary rollout of the Vault config, and removes the reset annotation if any
+func (r *ReconcileVault) startRollout(ctx context.Context, v *vaultv1alpha1.Vault, configSum string) (int32, error) {
+	status := &vaultv1alpha1.RolloutStatus{
+		Phase:         vaultv1alpha1.RolloutCanary,
+		ConfigSum:     configSum,
+		Partition:     v.Spec.Size - v.Spec.RolloutStrategy.GetCanaries(v.Spec.Size),
+		StepStartTime: ptr.To(metav1.Now()),
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RolloutStarted", "rolling out the new vault config to %d canary pods",
+		v.Spec.Size-status.Partition)
+
+	if _, ok := v.Annotations[rolloutResetAnnotation]; ok {
+		delete(v.Annotations, rolloutResetAnnotation)
+		if err := r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+			delete(v.Annotations, rolloutResetAnnotation)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return status.Partition, r.setRolloutStatus(ctx, v, status)
+}
+
+// reportBlockedRollout warns if the rolled back config holds back other changes of the Pod template, like the
+// restarts after a TLS certificate or a watched Secret has changed
+func (r *ReconcileVault) reportBlockedRollout(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet) error {
+	current := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(statefulSet), current); err != nil {
+		return fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	status := v.Status.Rollout.DeepCopy()
+	status.Message = ""
+	if podTemplateChangesHeldBack(current, statefulSet) {
+		status.Message = fmt.Sprintf("the changes of the pod template are held back by the rolled back config, "+
+			"revert the config or annotate the vault with %s to roll it out again", rolloutResetAnnotation)
+		if v.Status.Rollout.Message != status.Message {
+			r.recorder.Event(v, corev1.EventTypeWarning, "RolloutBlocked", status.Message)
+		}
+	}
+
+	return r.setRolloutStatus(ctx, v, status)
+}
+
+// podTemplateChangesHeldBack checks if the Pod template has changed apart from the Vault config
+func podTemplateChangesHeldBack(current, desired *appsv1.StatefulSet) bool {
+	currentAnnotations := maps.Clone(current.Spec.Template.Annotations)
+	desiredAnnotations := maps.Clone(desired.Spec.Template.Annotations)
+	delete(currentAnnotations, "vault.banzaicloud.io/vault-config")
+	delete(desiredAnnotations, "vault.banzaicloud.io/vault-config")
+
+	return !maps.Equal(currentAnnotations, desiredAnnotations)
+}
+
+// reconcileRollout drives a canary rollout one step forward, it returns true until the rollout is completed or rolled back
+func (r *ReconcileVault) reconcileRollout(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	status := v.Status.Rollout.DeepCopy()
+	strategy := v.Spec.RolloutStrategy
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, statefulSet); err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The StatefulSet controller has to pick up the current partition first
+	if !partitionApplied(statefulSet, status.Partition) {
+		return true, r.setRolloutStatus(ctx, v, status)
+	}
+
+	if status.Phase == vaultv1alpha1.RolloutRollingBack {
+		return false, r.rollBack(ctx, v, statefulSet, status)
+	}
+
+	// Every Pod from the partition up runs the new config, and has to be healthy
+	for i := status.Partition; i < v.Spec.Size; i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		if err := r.updatedPodHealthy(ctx, v, podName, statefulSet.Status.UpdateRevision); err != nil {
+			if status.StepStartTime != nil && time.Since(status.StepStartTime.Time) > strategy.GetHealthGateTimeout() {
+				status.Phase = vaultv1alpha1.RolloutRollingBack
+				status.Partition = v.Spec.Size
+				status.StepStartTime = nil
+				status.Message = fmt.Sprintf("%s is not healthy after %s: %v", podName, strategy.GetHealthGateTimeout(), err)
+				r.recorder.Event(v, corev1.EventTypeWarning, "RolloutFailed", status.Message+", rolling back")
+			}
+			return true, r.setRolloutStatus(ctx, v, status)
+		}
+	}
+
+	if status.Phase == vaultv1alpha1.RolloutCanary {
+		if status.StepStartTime != nil && time.Since(status.StepStartTime.Time) < strategy.GetPause() {
+			return true, r.setRolloutStatus(ctx, v, status)
+		}
+		status.Phase = vaultv1alpha1.RolloutProgressing
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "CanaryPassed", "the canary pods are healthy, rolling out the new vault config to the rest of the pods")
+	}
+
+	if status.Partition == 0 {
+		status.Phase = vaultv1alpha1.RolloutCompleted
+		status.StepStartTime = nil
+		r.recorder.Event(v, corev1.EventTypeNormal, "RolloutCompleted", "every vault pod runs the new config")
+		return false, r.setRolloutStatus(ctx, v, status)
+	}
+
+	status.Partition--
+	status.StepStartTime = ptr.To(metav1.Now())
+
+	return true, r.setRolloutStatus(ctx, v, status)
+}
+
+// rollBack restarts the Vault Pods running the new config, once the partition is raised above them
+// the StatefulSet controller recreates them with the previous config
+func (r *ReconcileVault) rollBack(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet, status *vaultv1alpha1.RolloutStatus) error {
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		for i := 0; i < int(v.Spec.Size); i++ {
+			pod := &corev1.Pod{}
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: fmt.Sprintf("%s-%d", v.Name, i)}, pod)
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if pod.Labels[appsv1.StatefulSetRevisionLabel] != statefulSet.Status.UpdateRevision {
+				continue
+			}
+
+			if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete pod %s: %v", pod.Name, err)
+			}
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "RolloutPodRolledBack", "%s is restarted with the previous config", pod.Name)
+		}
+	}
+
+	status.Phase = vaultv1alpha1.RolloutRolledBack
+	r.recorder.Event(v, corev1.EventTypeWarning, "RolloutRolledBack", "the vault config change has been rolled back, it is rolled out again once the config changes or the rollout is reset")
+
+	return r.setRolloutStatus(ctx, v, status)
+}
+
+func (r *ReconcileVault) setRolloutStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.RolloutStatus) error {
+	old := v.Status.Rollout
+	if old != nil && old.Phase == status.Phase {
+		status.LastTransitionTime = old.LastTransitionTime
+	} else {
+		status.LastTransitionTime = metav1.Now()
+	}
+
+	if reflect.DeepEqual(status, old) {
+		return nil
+	}
+
+	v.Status.Rollout = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Rollout = status
+	})
+}
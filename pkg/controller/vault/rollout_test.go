@@ -0,0 +1,130 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRollout(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 3,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+			RolloutStrategy: &vaultv1alpha1.RolloutStrategy{},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(int32(3))},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"vault.banzaicloud.io/vault-config": "old"},
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			CurrentRevision: "vault-old",
+			UpdateRevision:  "vault-new",
+		},
+	}
+	canary := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "vault-2", Namespace: "default", Labels: map[string]string{appsv1.StatefulSetRevisionLabel: "vault-new"},
+	}}
+	standby := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "vault-1", Namespace: "default", Labels: map[string]string{appsv1.StatefulSetRevisionLabel: "vault-old"},
+	}}
+
+	reconciler, c := newTestReconciler(v, statefulSet, canary, standby)
+
+	// The unchanged config is not rolled out
+	partition, err := reconciler.rolloutPartition(ctx, v, statefulSet, "old")
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), partition)
+	assert.Nil(t, v.Status.Rollout)
+
+	// The changed config goes to the canary first
+	partition, err = reconciler.rolloutPartition(ctx, v, statefulSet, "new")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), partition)
+	require.True(t, isRollingOut(v))
+	assert.Equal(t, vaultv1alpha1.RolloutCanary, v.Status.Rollout.Phase)
+
+	partition, err = reconciler.rolloutPartition(ctx, v, statefulSet, "new")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), partition)
+
+	// The failed canary is restarted with the previous config once the partition is raised again
+	v.Status.Rollout.Phase = vaultv1alpha1.RolloutRollingBack
+	v.Status.Rollout.Partition = 3
+	requeue, err := reconciler.reconcileRollout(ctx, v)
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	assert.Equal(t, vaultv1alpha1.RolloutRolledBack, v.Status.Rollout.Phase)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(canary), canary)))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(standby), standby))
+
+	// The rolled back config is not rolled out again
+	partition, err = reconciler.rolloutPartition(ctx, v, statefulSet, "new")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), partition)
+	assert.Empty(t, v.Status.Rollout.Message)
+
+	// Other changes of the Pod template are held back, and reported
+	desired := statefulSet.DeepCopy()
+	desired.Spec.Template.Annotations = map[string]string{
+		"vault.banzaicloud.io/vault-config":        "new",
+		"vault.banzaicloud.io/tls-expiration-date": "2030-01-01T00:00:00Z",
+	}
+	partition, err = reconciler.rolloutPartition(ctx, v, desired, "new")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), partition)
+	assert.Contains(t, v.Status.Rollout.Message, rolloutResetAnnotation)
+
+	// The reset rolls the config out to the canary again
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	v.Annotations = map[string]string{rolloutResetAnnotation: "true"}
+	require.NoError(t, c.Update(ctx, v))
+	partition, err = reconciler.rolloutPartition(ctx, v, desired, "new")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), partition)
+	assert.Equal(t, vaultv1alpha1.RolloutCanary, v.Status.Rollout.Phase)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	assert.NotContains(t, v.Annotations, rolloutResetAnnotation)
+
+	strategy := &vaultv1alpha1.RolloutStrategy{Canaries: ptr.To(int32(5))}
+	assert.Equal(t, int32(3), strategy.GetCanaries(3))
+}
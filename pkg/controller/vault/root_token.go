@@ -0,0 +1,119 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// externalConfigAppliedAnnotation on the Vault resource holds the checksum of the external configuration
+// the configurer has applied successfully
+const externalConfigAppliedAnnotation = "vault.banzaicloud.com/external-config-applied"
+
+// externalConfigSum returns the checksum of the external configuration of the Vault
+func externalConfigSum(v *vaultv1alpha1.Vault) string {
+	return fmt.Sprintf("%x", sha256.Sum256(v.Spec.ExternalConfigJSON()))
+}
+
+// reconcileRootTokenRevocation revokes the stored root token once Vault is initialized. The configurer authenticates
+// with the stored root token, so with an external configuration it is only revoked once the configurer has applied it.
+func (r *ReconcileVault) reconcileRootTokenRevocation(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	if v.Status.RootToken != nil && v.Status.RootToken.Revoked {
+		return nil
+	}
+
+	status := &vaultv1alpha1.RootTokenStatus{}
+
+	if len(v.Spec.ExternalConfig.Raw) != 0 && v.Annotations[externalConfigAppliedAnnotation] != externalConfigSum(v) {
+		status.Message = fmt.Sprintf("waiting for the configurer to apply externalConfig, it annotates the vault with %s=%s once it is applied",
+			externalConfigAppliedAnnotation, externalConfigSum(v))
+		return r.setRootTokenStatus(ctx, v, status)
+	}
+
+	namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	if !ok || v.Spec.UnsealConfig.HSM != nil {
+		status.Message = "revoking the root token is only supported if it is stored in a Kubernetes Secret"
+		return r.setRootTokenStatus(ctx, v, status)
+	}
+
+	secret := &corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	rootToken, ok := secret.Data["vault-root"]
+	if !ok {
+		return nil
+	}
+
+	vaultClient, err := ActiveVaultClient(ctx, r.client, v)
+	if err != nil || vaultClient == nil {
+		return err
+	}
+
+	vaultClient.SetToken(string(rootToken))
+	err = vaultClient.Auth().Token().RevokeSelf("")
+	var responseError *api.ResponseError
+	if err != nil && !(errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden) {
+		status.Message = fmt.Sprintf("failed to revoke root token: %v", err)
+		return r.setRootTokenStatus(ctx, v, status)
+	}
+
+	// The token is invalid from now on (or already was, in case of 403), so it's removed from the store
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret := &corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			return err
+		}
+		delete(secret.Data, "vault-root")
+		return r.nonNamespacedClient.Update(ctx, secret)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove root token from unseal keys secret: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "RootTokenRevoked", "root token revoked and removed from %s/%s", namespace, name)
+
+	status.Revoked = true
+	status.RevocationTime = metav1.Now()
+	return r.setRootTokenStatus(ctx, v, status)
+}
+
+func (r *ReconcileVault) setRootTokenStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.RootTokenStatus) error {
+	if v.Status.RootToken != nil && *v.Status.RootToken == *status {
+		return nil
+	}
+
+	v.Status.RootToken = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.RootToken = status
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRootTokenRevocationWaitsForExternalConfig(t *testing.T) {
+	ctx := context.Background()
+
+	revoked := false
+	fakeVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/sys/health":
+			_, _ = w.Write([]byte(`{"initialized": true, "sealed": false, "standby": false}`))
+		case "/v1/auth/token/revoke-self":
+			revoked = req.Header.Get("X-Vault-Token") == "root"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer fakeVault.Close()
+
+	podAddress := vaultPodAddress
+	vaultPodAddress = func(*vaultv1alpha1.Vault, string) string { return fakeVault.URL }
+	defer func() { vaultPodAddress = podAddress }()
+
+	revoke := true
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:           1,
+			Config:         extv1beta1.JSON{Raw: []byte(`{"listener": {"tcp": {"tls_disable": true}}}`)},
+			ExternalConfig: extv1beta1.JSON{Raw: []byte(`{"policies": []}`)},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Options:    vaultv1alpha1.UnsealOptions{RevokeRootToken: &revoke},
+				Kubernetes: vaultv1alpha1.KubernetesUnsealConfig{SecretNamespace: "default"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data:       map[string][]byte{"vault-root": []byte("root")},
+	}
+
+	r, c := newTestReconciler(v, secret)
+
+	require.NoError(t, r.reconcileRootTokenRevocation(ctx, v))
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	require.NotNil(t, v.Status.RootToken)
+	assert.False(t, v.Status.RootToken.Revoked)
+	assert.Contains(t, v.Status.RootToken.Message, externalConfigAppliedAnnotation)
+	assert.False(t, revoked)
+
+	// The configurer still finds the root token
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	assert.Equal(t, []byte("root"), secret.Data["vault-root"])
+
+	// Once the configurer has applied the external config, the root token is revoked
+	v.Annotations = map[string]string{externalConfigAppliedAnnotation: externalConfigSum(v)}
+	require.NoError(t, c.Update(ctx, v))
+	require.NoError(t, r.reconcileRootTokenRevocation(ctx, v))
+
+	assert.True(t, revoked)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	assert.True(t, v.Status.RootToken.Revoked)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	assert.NotContains(t, secret.Data, "vault-root")
+}
@@ -0,0 +1,264 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isSealMigrating checks if a seal migration is in progress
+func isSealMigrating(v *vaultv1alpha1.Vault) bool {
+	return v.Status.SealMigration != nil && v.Status.SealMigration.Phase == vaultv1alpha1.SealMigrationMigrating
+}
+
+// isAutoUnsealMode checks if the unseal sidecar runs in auto-unseal mode, during a seal migration
+// it keeps the mode of the old seal, the restarted Vault Pods are unsealed by the operator
+func isAutoUnsealMode(v *vaultv1alpha1.Vault) bool {
+	if isSealMigrating(v) {
+		return v.Status.SealMigration.From != vaultv1alpha1.ShamirSealType
+	}
+	return v.Spec.IsAutoUnseal()
+}
+
+// sealKeyPrefix returns the prefix of the stored keys which unseal (Shamir) or recover (auto-unseal) the seal type
+func sealKeyPrefix(sealType string) string {
+	if sealType == vaultv1alpha1.ShamirSealType {
+		return unsealKeyPrefix
+	}
+	return recoveryKeyPrefix
+}
+
+// StoredSealKeys returns the keys stored in the Secret data which unseal (Shamir) or recover (auto-unseal) the Vault,
+// during a seal migration these are still the keys of the old seal
+func StoredSealKeys(v *vaultv1alpha1.Vault, data map[string][]byte) []string {
+	sealType := v.Spec.GetSealType()
+	if isSealMigrating(v) {
+		sealType = v.Status.SealMigration.From
+	}
+	return storedKeys(data, sealKeyPrefix(sealType))
+}
+
+// detectSealMigration compares the seal in the Vault config with the seal of the running Vault Pods,
+// and starts a migration if they differ. It has to run before the Vault Pods pick up the new config,
+// which mustn't happen if it reports that the seal change isn't supported.
+func (r *ReconcileVault) detectSealMigration(ctx context.Context, v *vaultv1alpha1.Vault, configSum string) (bool, error) {
+	if isSealMigrating(v) {
+		return false, nil
+	}
+
+	// The seal can only change together with the config of the running Vault Pods
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, statefulSet)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if statefulSet.Spec.Template.Annotations["vault.banzaicloud.io/vault-config"] == configSum {
+		return false, nil
+	}
+
+	current := ""
+	for i := 0; i < int(v.Spec.Size); i++ {
+		vaultClient, err := newInsecureVaultClientForPod(v, fmt.Sprintf("%s-%d", v.Name, i))
+		if err != nil {
+			return false, err
+		}
+
+		sealStatus, err := vaultClient.Sys().SealStatus()
+		if err == nil && sealStatus.Initialized && !sealStatus.Sealed {
+			current = sealStatus.Type
+			break
+		}
+	}
+
+	desired := v.Spec.GetSealType()
+	if current == "" {
+		return false, nil
+	}
+
+	// The seal in the spec has been set back to the running one
+	if current == desired {
+		if old := v.Status.SealMigration; old != nil && old.Phase == vaultv1alpha1.SealMigrationFailed && old.To != desired {
+			return false, r.setSealMigrationStatus(ctx, v, nil)
+		}
+		return false, nil
+	}
+
+	status := &vaultv1alpha1.SealMigrationStatus{
+		Phase: vaultv1alpha1.SealMigrationMigrating,
+		From:  current,
+		To:    desired,
+	}
+
+	_, _, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	switch {
+	case current != vaultv1alpha1.ShamirSealType && desired != vaultv1alpha1.ShamirSealType:
+		status.Phase = vaultv1alpha1.SealMigrationFailed
+		status.Message = "only migrations between Shamir and auto-unseal are supported"
+	case !ok || v.Spec.UnsealConfig.HSM != nil:
+		status.Phase = vaultv1alpha1.SealMigrationFailed
+		status.Message = "seal migration is only supported if the unseal keys are stored in a Kubernetes Secret"
+	}
+
+	failed := status.Phase == vaultv1alpha1.SealMigrationFailed
+	old := v.Status.SealMigration
+	if old != nil && old.Phase == status.Phase && old.From == status.From && old.To == status.To && old.Message == status.Message {
+		return failed, nil
+	}
+
+	if status.Phase == vaultv1alpha1.SealMigrationMigrating {
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigrationStarted", "migrating the seal from %s to %s", current, desired)
+	} else {
+		r.recorder.Event(v, corev1.EventTypeWarning, "SealMigrationFailed", status.Message)
+	}
+
+	return failed, r.setSealMigrationStatus(ctx, v, status)
+}
+
+// reconcileSealMigration unseals the Vault Pods restarted with the new seal using the keys of the old seal,
+// and once every Pod runs with the new seal, stores the keys under the names of the new seal
+func (r *ReconcileVault) reconcileSealMigration(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	status := v.Status.SealMigration.DeepCopy()
+
+	namespace, name, _ := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return false, fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	keys := storedKeys(secret.Data, sealKeyPrefix(status.From))
+	if len(keys) == 0 {
+		status.Phase = vaultv1alpha1.SealMigrationFailed
+		status.Message = fmt.Sprintf("secret %s/%s holds no %s keys", namespace, name, sealKeyPrefix(status.From))
+		r.recorder.Event(v, corev1.EventTypeWarning, "SealMigrationFailed", status.Message)
+		return false, r.setSealMigrationStatus(ctx, v, status)
+	}
+
+	pending := false
+	status.Message = ""
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		vaultClient, err := NewVaultClientForPod(ctx, r.client, v, podName)
+		if err != nil {
+			return false, err
+		}
+
+		sealStatus, err := vaultClient.Sys().SealStatus()
+		if err != nil {
+			pending = true
+			continue
+		}
+
+		if sealStatus.Sealed && sealStatus.Migration {
+			if err := unsealWithKeys(vaultClient, keys, true); err != nil {
+				status.Message = fmt.Sprintf("failed to migrate %s: %v", podName, err)
+				pending = true
+				continue
+			}
+
+			status.MigratedPods = appendIfMissing(status.MigratedPods, podName)
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigrated", "%s unsealed with -migrate", podName)
+			continue
+		}
+
+		// Not restarted with the new seal yet
+		if sealStatus.Sealed || sealStatus.Type != status.To {
+			pending = true
+		}
+	}
+
+	if pending {
+		return true, r.setSealMigrationStatus(ctx, v, status)
+	}
+
+	// The keys of the old seal are the keys of the new seal from now on (unseal keys become recovery keys, or vice versa)
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret := &corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			return err
+		}
+		data := withStoredKeys(secret.Data, sealKeyPrefix(status.From), nil)
+		secret.Data = withStoredKeys(data, sealKeyPrefix(status.To), keys)
+		return r.nonNamespacedClient.Update(ctx, secret)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to store the keys of the new seal: %v", err)
+	}
+
+	status.Phase = vaultv1alpha1.SealMigrationCompleted
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "SealMigrationCompleted", "seal migrated from %s to %s", status.From, status.To)
+
+	return false, r.setSealMigrationStatus(ctx, v, status)
+}
+
+// unsealWithKeys submits the stored keys until the Vault Pod is unsealed, during a seal migration
+// the keys of the old seal are submitted with the migrate flag
+func unsealWithKeys(vaultClient *api.Client, keys []string, migrate bool) error {
+	for _, key := range keys {
+		sealStatus, err := vaultClient.Sys().UnsealWithOptions(&api.UnsealOpts{Key: key, Migrate: migrate})
+		if err != nil {
+			_, _ = vaultClient.Sys().ResetUnsealProcess()
+			return err
+		}
+		if !sealStatus.Sealed {
+			return nil
+		}
+	}
+
+	_, _ = vaultClient.Sys().ResetUnsealProcess()
+	return fmt.Errorf("the stored keys couldn't unseal Vault")
+}
+
+func appendIfMissing(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+func (r *ReconcileVault) setSealMigrationStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.SealMigrationStatus) error {
+	old := v.Status.SealMigration
+	if status != nil {
+		if old != nil && old.Phase == status.Phase {
+			status.LastTransitionTime = old.LastTransitionTime
+		} else {
+			status.LastTransitionTime = metav1.Now()
+		}
+	}
+
+	if reflect.DeepEqual(status, old) {
+		return nil
+	}
+
+	v.Status.SealMigration = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.SealMigration = status
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestSealMigrationUnsealMode(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}, "seal": {"awskms": {"kms_key_id": "key"}}}`),
+			},
+		},
+	}
+
+	unsealArgs := func() []string {
+		statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+		require.NoError(t, err)
+		for _, container := range statefulSet.Spec.Template.Spec.Containers {
+			if container.Name == "bank-vaults" {
+				return container.Command
+			}
+		}
+		return nil
+	}
+
+	assert.Contains(t, unsealArgs(), "--auto")
+
+	// The sidecar keeps the mode of the old seal until the migration has completed
+	v.Status.SealMigration = &vaultv1alpha1.SealMigrationStatus{
+		Phase: vaultv1alpha1.SealMigrationMigrating,
+		From:  vaultv1alpha1.ShamirSealType,
+		To:    "awskms",
+	}
+	assert.NotContains(t, unsealArgs(), "--auto")
+
+	v.Status.SealMigration.Phase = vaultv1alpha1.SealMigrationCompleted
+	assert.Contains(t, unsealArgs(), "--auto")
+
+	assert.Equal(t, "vault-recovery-", sealKeyPrefix("awskms"))
+	assert.Equal(t, "vault-unseal-", sealKeyPrefix(vaultv1alpha1.ShamirSealType))
+
+	// The keys of the old seal are stored until the migration has completed
+	data := map[string][]byte{"vault-unseal-0": []byte("unseal"), "vault-recovery-0": []byte("recovery")}
+	assert.Equal(t, []string{"recovery"}, StoredSealKeys(v, data))
+	v.Status.SealMigration.Phase = vaultv1alpha1.SealMigrationMigrating
+	assert.Equal(t, []string{"unseal"}, StoredSealKeys(v, data))
+}
+
+func TestDetectUnsupportedSealMigration(t *testing.T) {
+	ctx := context.Background()
+
+	fakeVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"type": "awskms", "initialized": true, "sealed": false}`))
+	}))
+	defer fakeVault.Close()
+
+	podAddress := vaultPodAddress
+	vaultPodAddress = func(*vaultv1alpha1.Vault, string) string { return fakeVault.URL }
+	defer func() { vaultPodAddress = podAddress }()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "seal": {"gcpckms": {"key_ring": "vault"}}}`),
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"vault.banzaicloud.io/vault-config": "running"}},
+			},
+		},
+	}
+
+	r, _ := newTestReconciler(v, statefulSet)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	// A migration between two auto-unseal seals keeps the Vault Pods on the running config
+	unsupported, err := r.detectSealMigration(ctx, v, "changed")
+	require.NoError(t, err)
+	assert.True(t, unsupported)
+	require.NotNil(t, v.Status.SealMigration)
+	assert.Equal(t, vaultv1alpha1.SealMigrationFailed, v.Status.SealMigration.Phase)
+	assert.Contains(t, <-recorder.Events, "SealMigrationFailed")
+
+	// Until the seal in the spec is set back to the running one
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "seal": {"awskms": {"kms_key_id": "key"}}}`)}
+	unsupported, err = r.detectSealMigration(ctx, v, "fixed")
+	require.NoError(t, err)
+	assert.False(t, unsupported)
+	assert.Nil(t, v.Status.SealMigration)
+}
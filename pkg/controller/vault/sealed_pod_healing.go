@@ -0,0 +1,165 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileSealedPodHealing tracks the sealed Vault Pods based on their last health check, and heals the ones which
+// stay sealed past the grace period: they are re-unsealed with the stored unseal keys if possible, deleted otherwise.
+// Pods which couldn't be checked keep their state. It returns when the sealed Pods have to be checked again.
+// Nothing is healed during a seal migration, an upgrade or a rollout.
+func (r *ReconcileVault) reconcileSealedPodHealing(ctx context.Context, v *vaultv1alpha1.Vault, healths map[string]*api.HealthResponse) (time.Duration, error) {
+	// The Pods are sealed on purpose while they wait for the migration keys or restart with a new revision,
+	// they are tracked from scratch afterwards
+	if isSealMigrating(v) || isUpgrading(v) || isRollingOut(v) {
+		return 0, r.setSealedPodsStatus(ctx, v, nil)
+	}
+
+	healing := v.Spec.SealedPodHealing
+	now := metav1.Now()
+
+	previous := map[string]vaultv1alpha1.SealedPodStatus{}
+	for _, status := range v.Status.SealedPods {
+		previous[status.Name] = status
+	}
+
+	var statuses []vaultv1alpha1.SealedPodStatus
+	var requeueAfter time.Duration
+	requeueIn := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	for i := 0; i < int(v.Spec.Size); i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		status, tracked := previous[podName]
+
+		health, probed := healths[podName]
+		if !probed {
+			// Not reachable (for example restarting), will be checked again
+			if tracked {
+				statuses = append(statuses, status)
+				requeueIn(healing.GetGracePeriod())
+			}
+			continue
+		}
+
+		if !health.Initialized || !health.Sealed {
+			if tracked {
+				r.recorder.Eventf(v, corev1.EventTypeNormal, "SealedPodRecovered", "%s is unsealed again, it was sealed since %s", podName, status.SealedSince.Format(time.RFC3339))
+			}
+			continue
+		}
+
+		if !tracked {
+			status = vaultv1alpha1.SealedPodStatus{Name: podName, SealedSince: now}
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "PodSealed", "%s is sealed, it is healed if it stays sealed for %s", podName, healing.GetGracePeriod())
+		}
+
+		next := status.SealedSince.Add(healing.GetGracePeriod())
+		if status.LastRestartTime != nil {
+			if backoff := status.LastRestartTime.Add(healing.GetRestartBackoff(status.Restarts)); backoff.After(next) {
+				next = backoff
+			}
+		}
+
+		if now.Time.Before(next) {
+			statuses = append(statuses, status)
+			requeueIn(next.Sub(now.Time))
+			continue
+		}
+
+		err := r.unsealSealedPod(ctx, v, podName)
+		if err == nil {
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "SealedPodUnsealed", "%s was sealed since %s, re-unsealed it with the stored unseal keys", podName, status.SealedSince.Format(time.RFC3339))
+			continue
+		}
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: v.Namespace}}
+		if deleteErr := r.client.Delete(ctx, pod); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			status.Message = fmt.Sprintf("failed to delete the pod: %v", deleteErr)
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "SealedPodDeleteFailed", "%s is sealed since %s, failed to delete it: %v", podName, status.SealedSince.Format(time.RFC3339), deleteErr)
+			statuses = append(statuses, status)
+			requeueIn(healing.GetGracePeriod())
+			continue
+		}
+
+		status.Restarts++
+		status.LastRestartTime = &now
+		status.Message = fmt.Sprintf("couldn't re-unseal: %v", err)
+		r.recorder.Eventf(v, corev1.EventTypeWarning, "SealedPodDeleted", "%s is sealed since %s and couldn't be re-unsealed (%v), deleted it (%d times so far), next attempt not before %s",
+			podName, status.SealedSince.Format(time.RFC3339), err, status.Restarts, healing.GetRestartBackoff(status.Restarts))
+
+		statuses = append(statuses, status)
+		requeueIn(healing.GetRestartBackoff(status.Restarts))
+	}
+
+	return requeueAfter, r.setSealedPodsStatus(ctx, v, statuses)
+}
+
+// unsealSealedPod unseals a Vault Pod with the unseal keys stored in the Kubernetes Secret,
+// with auto-unseal or other backends this is not possible through the API
+func (r *ReconcileVault) unsealSealedPod(ctx context.Context, v *vaultv1alpha1.Vault, podName string) error {
+	if v.Spec.IsAutoUnseal() {
+		return fmt.Errorf("vault is auto-unsealed, it can't be unsealed with keys")
+	}
+
+	namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	if !ok || v.Spec.UnsealConfig.HSM != nil {
+		return fmt.Errorf("the unseal keys are not stored in a Kubernetes Secret")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	keys := unsealKeys(secret.Data)
+	if len(keys) == 0 {
+		return fmt.Errorf("secret %s/%s holds no unseal keys", namespace, name)
+	}
+
+	vaultClient, err := NewVaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return err
+	}
+
+	return unsealWithKeys(vaultClient, keys, false)
+}
+
+func (r *ReconcileVault) setSealedPodsStatus(ctx context.Context, v *vaultv1alpha1.Vault, statuses []vaultv1alpha1.SealedPodStatus) error {
+	if reflect.DeepEqual(statuses, v.Status.SealedPods) {
+		return nil
+	}
+
+	v.Status.SealedPods = statuses
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.SealedPods = statuses
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSealedPodHealing(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 2,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "seal": {"awskms": {"kms_key_id": "key"}}}`),
+			},
+			SealedPodHealing: &vaultv1alpha1.SealedPodHealing{},
+		},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "default"}}
+
+	reconciler, c := newTestReconciler(v, pod)
+
+	healths := map[string]*api.HealthResponse{
+		"vault-0": {Initialized: true, Sealed: true},
+		"vault-1": {Initialized: true, Sealed: false},
+	}
+
+	// A newly sealed Pod is only tracked during the grace period
+	requeueAfter, err := reconciler.reconcileSealedPodHealing(ctx, v, healths)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, requeueAfter)
+	require.Len(t, v.Status.SealedPods, 1)
+	assert.Equal(t, "vault-0", v.Status.SealedPods[0].Name)
+	assert.Equal(t, 0, v.Status.SealedPods[0].Restarts)
+
+	// Past the grace period the auto-unsealed Pod can't be re-unsealed, so it is deleted
+	v.Status.SealedPods[0].SealedSince = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	requeueAfter, err = reconciler.reconcileSealedPodHealing(ctx, v, healths)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, requeueAfter)
+	require.Len(t, v.Status.SealedPods, 1)
+	assert.Equal(t, 1, v.Status.SealedPods[0].Restarts)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(pod), pod)))
+
+	// The restarting Pod keeps its state until it can be checked again
+	delete(healths, "vault-0")
+	_, err = reconciler.reconcileSealedPodHealing(ctx, v, healths)
+	require.NoError(t, err)
+	require.Len(t, v.Status.SealedPods, 1)
+	assert.Equal(t, 1, v.Status.SealedPods[0].Restarts)
+
+	// Once unsealed it is not tracked anymore
+	healths["vault-0"] = &api.HealthResponse{Initialized: true, Sealed: false}
+	requeueAfter, err = reconciler.reconcileSealedPodHealing(ctx, v, healths)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Empty(t, v.Status.SealedPods)
+}
+
+func TestSealedPodHealingSkippedDuringSealMigration(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:             1,
+			SealedPodHealing: &vaultv1alpha1.SealedPodHealing{},
+		},
+		Status: vaultv1alpha1.VaultStatus{
+			SealMigration: &vaultv1alpha1.SealMigrationStatus{Phase: vaultv1alpha1.SealMigrationMigrating},
+			SealedPods: []vaultv1alpha1.SealedPodStatus{
+				{Name: "vault-0", SealedSince: metav1.NewTime(time.Now().Add(-time.Hour))},
+			},
+		},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "default"}}
+
+	reconciler, c := newTestReconciler(v, pod)
+
+	// The Pod waits for the migration keys, it is neither unsealed nor deleted
+	healths := map[string]*api.HealthResponse{"vault-0": {Initialized: true, Sealed: true}}
+	requeueAfter, err := reconciler.reconcileSealedPodHealing(ctx, v, healths)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Empty(t, v.Status.SealedPods)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), pod))
+}
+
+func TestSealedPodHealingRestartBackoff(t *testing.T) {
+	healing := &vaultv1alpha1.SealedPodHealing{}
+	assert.Equal(t, time.Minute, healing.GetRestartBackoff(1))
+	assert.Equal(t, 4*time.Minute, healing.GetRestartBackoff(3))
+	assert.Equal(t, time.Hour, healing.GetRestartBackoff(10))
+}
@@ -0,0 +1,164 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// immutableStatefulSetChanges returns the immutable fields of the StatefulSet which differ,
+// the sizes of the volume claim templates are handled by the volume expansion
+func immutableStatefulSetChanges(current, desired *appsv1.StatefulSet) []string {
+	var changes []string
+
+	if !equality.Semantic.DeepEqual(current.Spec.Selector, desired.Spec.Selector) {
+		changes = append(changes, "selector")
+	}
+
+	podManagementPolicy := func(statefulSet *appsv1.StatefulSet) appsv1.PodManagementPolicyType {
+		if statefulSet.Spec.PodManagementPolicy == "" {
+			return appsv1.OrderedReadyPodManagement
+		}
+		return statefulSet.Spec.PodManagementPolicy
+	}
+	if podManagementPolicy(current) != podManagementPolicy(desired) {
+		changes = append(changes, "podManagementPolicy")
+	}
+
+	if current.Spec.ServiceName != desired.Spec.ServiceName {
+		changes = append(changes, "serviceName")
+	}
+
+	currentTemplates := map[string]bool{}
+	for _, template := range current.Spec.VolumeClaimTemplates {
+		currentTemplates[template.Name] = true
+	}
+	templatesChanged := len(current.Spec.VolumeClaimTemplates) != len(desired.Spec.VolumeClaimTemplates)
+	for _, template := range desired.Spec.VolumeClaimTemplates {
+		templatesChanged = templatesChanged || !currentTemplates[template.Name]
+	}
+	if templatesChanged {
+		changes = append(changes, "volumeClaimTemplates")
+	}
+
+	return changes
+}
+
+// isImmutableFieldError tells whether an update of a StatefulSet has been refused because of an immutable field
+func isImmutableFieldError(err error) bool {
+	return apierrors.IsInvalid(err) && strings.Contains(err.Error(), "updates to statefulset spec for fields other than")
+}
+
+// reconcileImmutableFields recreates the StatefulSet if an immutable field has changed,
+// it returns true while the StatefulSet is being deleted
+func (r *ReconcileVault) reconcileImmutableFields(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet) (bool, error) {
+	current := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(statefulSet), current)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The orphaned Pods are adopted by the new StatefulSet once the old one is gone
+	if current.DeletionTimestamp != nil {
+		return true, nil
+	}
+
+	changes := immutableStatefulSetChanges(current, statefulSet)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	return true, r.recreateStatefulSet(ctx, v, current, fmt.Sprintf("the immutable fields %s have changed", strings.Join(changes, ", ")))
+}
+
+// recreateStatefulSet deletes the StatefulSet leaving its Pods running (like kubectl delete --cascade=orphan),
+// it is created again with the immutable fields changed in the next reconcile
+func (r *ReconcileVault) recreateStatefulSet(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet, reason string) error {
+	err := r.client.Delete(ctx, statefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete StatefulSet: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "StatefulSetRecreated", "%s, the StatefulSet is recreated, its Pods are kept running", reason)
+
+	return nil
+}
+
+// rollOrphanedPods deletes the Pods left running by a recreated StatefulSet which its selector doesn't match,
+// so they are replaced by the StatefulSet. They are deleted one at a time in ordinal order, after the Pods before
+// them have become ready. It returns true while some Pods are still waiting to be replaced.
+func (r *ReconcileVault) rollOrphanedPods(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+
+	ready := true
+	for i := 0; i < int(v.Spec.Size); i++ {
+		pod := &corev1.Pod{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: fmt.Sprintf("%s-%d", v.Name, i)}, pod)
+		if apierrors.IsNotFound(err) {
+			ready = false
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get pod: %v", err)
+		}
+
+		if selector.Matches(labels.Set(pod.Labels)) {
+			ready = ready && podReady(pod)
+			continue
+		}
+
+		if pod.DeletionTimestamp != nil || !ready {
+			return true, nil
+		}
+
+		if _, err := r.stepDownIfActive(ctx, v, pod.Name); err != nil {
+			log.Error(err, "failed to step down the active vault before replacing it", "pod", pod.Name)
+		}
+
+		if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete orphaned pod: %v", err)
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "OrphanedPodReplaced", "pod %s isn't matched by the recreated StatefulSet, it is replaced", pod.Name)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestImmutableStatefulSetChanges(t *testing.T) {
+	current := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Selector:            &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "vault"}},
+			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "vault-raft"}},
+			},
+		},
+	}
+
+	desired := current.DeepCopy()
+	desired.Spec.PodManagementPolicy = ""
+	assert.Empty(t, immutableStatefulSetChanges(current, desired))
+
+	desired.Spec.Selector.MatchLabels["tier"] = "vault"
+	desired.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	desired.Spec.ServiceName = "vault"
+	desired.Spec.VolumeClaimTemplates[0].Name = "vault-data"
+	assert.Equal(t, []string{"selector", "podManagementPolicy", "serviceName", "volumeClaimTemplates"}, immutableStatefulSetChanges(current, desired))
+}
+
+func TestRecreateStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultSpec{Size: 3},
+	}
+	oldLabels := map[string]string{"app.kubernetes.io/name": "vault"}
+	newLabels := map[string]string{"app.kubernetes.io/name": "vault", "tier": "vault"}
+	current := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: oldLabels}},
+	}
+
+	readyPod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	r, c := newTestReconciler(
+		v, current,
+		readyPod("vault-0", newLabels), readyPod("vault-1", oldLabels), readyPod("vault-2", oldLabels),
+	)
+
+	desired := current.DeepCopy()
+	desired.Spec.Selector.MatchLabels = newLabels
+
+	// The StatefulSet is deleted with its Pods left running
+	recreating, err := r.reconcileImmutableFields(ctx, v, desired)
+	require.NoError(t, err)
+	assert.True(t, recreating)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(current), &appsv1.StatefulSet{})))
+
+	recreating, err = r.reconcileImmutableFields(ctx, v, desired)
+	require.NoError(t, err)
+	assert.False(t, recreating)
+
+	// Only the first orphaned Pod is replaced
+	pending, err := r.rollOrphanedPods(ctx, v, desired)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	for i, deleted := range []bool{false, true, false} {
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: fmt.Sprintf("vault-%d", i)}, &corev1.Pod{})
+		assert.Equal(t, deleted, apierrors.IsNotFound(err), "vault-%d", i)
+	}
+
+	// The next one waits for the replacement
+	pending, err = r.rollOrphanedPods(ctx, v, desired)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-2"}, &corev1.Pod{}))
+}
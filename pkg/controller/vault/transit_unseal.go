@@ -0,0 +1,394 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// The keys of the transit unseal Secret
+	transitTokenKey            = "token"
+	transitAccessorKey         = "accessor"
+	transitPreviousAccessorKey = "previousAccessor"
+	transitIssueTimeKey        = "issueTime"
+	transitAddressKey          = "address"
+	transitCACertKey           = "ca.crt"
+	transitCACertFileKey       = "caCertFile"
+
+	transitUnsealVolumeName = "vault-transit-unseal"
+	transitUnsealMountPath  = "/vault/transit-unseal"
+)
+
+func transitUnsealSecretName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-transit-unseal"
+}
+
+func transitUnsealPolicyName(v *vaultv1alpha1.Vault) string {
+	return fmt.Sprintf("transit-unseal-%s-%s", v.Namespace, v.Name)
+}
+
+// withTransitUnsealEnv passes the transit token to Vault
+func withTransitUnsealEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	if v.Spec.UnsealConfig.Transit != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name: "VAULT_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: transitUnsealSecretName(v)},
+					Key:                  transitTokenKey,
+				},
+			},
+		})
+	}
+	return envs
+}
+
+// withTransitUnsealTemplateEnv passes the address and CA certificate of the unsealing Vault to the config templating
+func withTransitUnsealTemplateEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	if v.Spec.UnsealConfig.Transit != nil {
+		for _, env := range [][2]string{{"VAULT_TRANSIT_ADDRESS", transitAddressKey}, {"VAULT_TRANSIT_CA_CERT", transitCACertFileKey}} {
+			envs = append(envs, corev1.EnvVar{
+				Name: env[0],
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: transitUnsealSecretName(v)},
+						Key:                  env[1],
+					},
+				},
+			})
+		}
+	}
+	return envs
+}
+
+func withTransitUnsealVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if v.Spec.UnsealConfig.Transit != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: transitUnsealVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: transitUnsealSecretName(v),
+					Items:      []corev1.KeyToPath{{Key: transitCACertKey, Path: transitCACertKey}},
+					// The unsealing Vault may run without TLS
+					Optional: ptr.To(true),
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func withTransitUnsealVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if v.Spec.UnsealConfig.Transit != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      transitUnsealVolumeName,
+			MountPath: transitUnsealMountPath,
+		})
+	}
+	return volumeMounts
+}
+
+// reconcileTransitUnseal sets up the transit secrets engine on the unsealing Vault, and issues (or rotates) the token
+// used by this Vault to call it. It returns the accessor of the current token, which restarts the Vault Pods when changed.
+func (r *ReconcileVault) reconcileTransitUnseal(ctx context.Context, v *vaultv1alpha1.Vault) (string, error) {
+	transit := v.Spec.UnsealConfig.Transit
+	status := &vaultv1alpha1.TransitUnsealStatus{}
+
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: transitUnsealSecretName(v)}, secret)
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get transit unseal secret: %v", err)
+	}
+
+	var data map[string][]byte
+	issueTime := time.Time{}
+	if secret != nil {
+		data = secret.Data
+		issueTime, _ = time.Parse(time.RFC3339, string(data[transitIssueTimeKey]))
+		status.TokenAccessor = string(data[transitAccessorKey])
+		status.TokenIssueTime = metav1.NewTime(issueTime)
+	}
+	accessor := status.TokenAccessor
+
+	unsealer := &vaultv1alpha1.Vault{}
+	unsealerKey := client.ObjectKey{Namespace: transit.VaultRef.Namespace, Name: transit.VaultRef.Name}
+	if unsealerKey.Namespace == "" {
+		unsealerKey.Namespace = v.Namespace
+	}
+	if err := r.nonNamespacedClient.Get(ctx, unsealerKey, unsealer); err != nil {
+		status.Message = fmt.Sprintf("failed to get unsealing vault %s: %v", unsealerKey, err)
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	// A token is only rotated after the previous one has been revoked
+	previousAccessor := string(data[transitPreviousAccessorKey])
+	rotate := len(data[transitTokenKey]) == 0 || (previousAccessor == "" && time.Since(issueTime) > transit.GetTokenRotationPeriod())
+	if !rotate && (previousAccessor == "" || !r.vaultRolledOut(ctx, v)) {
+		// Keep the address and CA certificate of the unsealing Vault up to date
+		unsealerData, err := r.transitUnsealerData(ctx, unsealer)
+		if err != nil {
+			return accessor, err
+		}
+
+		changed := false
+		for key, value := range unsealerData {
+			if string(secret.Data[key]) != string(value) {
+				secret.Data[key] = value
+				changed = true
+			}
+		}
+		if changed {
+			if err := r.client.Update(ctx, secret); err != nil {
+				return accessor, fmt.Errorf("failed to update transit unseal secret: %v", err)
+			}
+		}
+
+		status.Message = ""
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	adminClient, err := ActiveVaultClient(ctx, r.nonNamespacedClient, unsealer)
+	if err != nil {
+		return accessor, err
+	}
+	if adminClient == nil {
+		status.Message = fmt.Sprintf("waiting for the unsealing vault %s to become active", unsealerKey)
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	adminToken, err := r.transitAdminToken(ctx, v, unsealer)
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to get token for the unsealing vault: %v", err)
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+	adminClient.SetToken(adminToken)
+
+	if !rotate {
+		// Every Vault Pod runs with the new token, so the previous one can go
+		if err := adminClient.Auth().Token().RevokeAccessor(previousAccessor); err != nil && !isInvalidAccessor(err) {
+			status.Message = fmt.Sprintf("failed to revoke previous transit token: %v", err)
+			return accessor, r.setTransitUnsealStatus(ctx, v, status)
+		}
+
+		delete(secret.Data, transitPreviousAccessorKey)
+		if err := r.client.Update(ctx, secret); err != nil {
+			return accessor, fmt.Errorf("failed to update transit unseal secret: %v", err)
+		}
+
+		status.Message = ""
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	if err := ensureTransitEngine(adminClient, transit.GetMountPath(), transit.GetKeyName(v), transitUnsealPolicyName(v)); err != nil {
+		status.Message = err.Error()
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	token, err := adminClient.Auth().Token().CreateOrphan(&api.TokenCreateRequest{
+		Policies:        []string{transitUnsealPolicyName(v)},
+		Period:          transit.GetTokenPeriod().String(),
+		NoDefaultPolicy: true,
+		Renewable:       ptr.To(true),
+		DisplayName:     transitUnsealPolicyName(v),
+	})
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to create transit token: %v", err)
+		return accessor, r.setTransitUnsealStatus(ctx, v, status)
+	}
+
+	newData, err := r.transitUnsealerData(ctx, unsealer)
+	if err != nil {
+		return accessor, err
+	}
+	now := time.Now()
+	newData[transitTokenKey] = []byte(token.Auth.ClientToken)
+	newData[transitAccessorKey] = []byte(token.Auth.Accessor)
+	newData[transitIssueTimeKey] = []byte(now.UTC().Format(time.RFC3339))
+	if accessor != "" {
+		// Revoked once the Vault Pods have been restarted with the new token
+		newData[transitPreviousAccessorKey] = []byte(accessor)
+	}
+
+	newSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      transitUnsealSecretName(v),
+			Namespace: v.Namespace,
+			Labels:    withVaultLabels(v, v.LabelsForVault()),
+		},
+		Data: newData,
+	}
+	if err := controllerutil.SetControllerReference(v, newSecret, r.scheme); err != nil {
+		return accessor, err
+	}
+	if err := r.createOrUpdateObject(ctx, newSecret); err != nil {
+		_ = adminClient.Auth().Token().RevokeAccessor(token.Auth.Accessor)
+		return accessor, fmt.Errorf("failed to create/update transit unseal secret: %v", err)
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "TransitTokenIssued", "transit unseal token %s issued by %s", token.Auth.Accessor, unsealerKey)
+
+	status.TokenAccessor = token.Auth.Accessor
+	status.TokenIssueTime = metav1.NewTime(now)
+	status.Message = ""
+	return token.Auth.Accessor, r.setTransitUnsealStatus(ctx, v, status)
+}
+
+// ensureTransitEngine mounts the transit secrets engine, creates the key and a policy allowing only encryption
+// and decryption with it on the unsealing Vault
+func ensureTransitEngine(adminClient *api.Client, mountPath, keyName, policyName string) error {
+	mounts, err := adminClient.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("failed to list mounts: %v", err)
+	}
+
+	if _, ok := mounts[mountPath+"/"]; !ok {
+		if err := adminClient.Sys().Mount(mountPath, &api.MountInput{Type: "transit", Description: "Transit auto-unseal"}); err != nil {
+			return fmt.Errorf("failed to mount transit secrets engine: %v", err)
+		}
+	}
+
+	key, err := adminClient.Logical().Read(fmt.Sprintf("%s/keys/%s", mountPath, keyName))
+	if err != nil {
+		return fmt.Errorf("failed to read transit key: %v", err)
+	}
+	if key == nil {
+		if _, err := adminClient.Logical().Write(fmt.Sprintf("%s/keys/%s", mountPath, keyName), map[string]interface{}{"type": "aes256-gcm96"}); err != nil {
+			return fmt.Errorf("failed to create transit key: %v", err)
+		}
+	}
+
+	policy := fmt.Sprintf("path %q {\n  capabilities = [\"update\"]\n}\npath %q {\n  capabilities = [\"update\"]\n}\n",
+		fmt.Sprintf("%s/encrypt/%s", mountPath, keyName), fmt.Sprintf("%s/decrypt/%s", mountPath, keyName))
+	if err := adminClient.Sys().PutPolicy(policyName, policy); err != nil {
+		return fmt.Errorf("failed to write transit policy: %v", err)
+	}
+
+	return nil
+}
+
+// transitUnsealerData returns the address and CA certificate of the unsealing Vault
+func (r *ReconcileVault) transitUnsealerData(ctx context.Context, unsealer *vaultv1alpha1.Vault) (map[string][]byte, error) {
+	data := map[string][]byte{
+		transitAddressKey:    []byte(fmt.Sprintf("%s://%s.%s:8200", strings.ToLower(string(getVaultURIScheme(unsealer))), unsealer.Name, unsealer.Namespace)),
+		transitCACertFileKey: []byte(""),
+	}
+
+	if !unsealer.Spec.IsTLSDisabled() {
+		tlsSecretName := unsealer.Name + "-tls"
+		if unsealer.Spec.ExistingTLSSecretName != "" {
+			tlsSecretName = unsealer.Spec.ExistingTLSSecretName
+		}
+
+		tlsSecret := &corev1.Secret{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: unsealer.Namespace, Name: tlsSecretName}, tlsSecret); err != nil {
+			return nil, fmt.Errorf("failed to get tls secret of the unsealing vault: %v", err)
+		}
+
+		data[transitCACertKey] = tlsSecret.Data[transitCACertKey]
+		data[transitCACertFileKey] = []byte(transitUnsealMountPath + "/" + transitCACertKey)
+	}
+
+	return data, nil
+}
+
+// transitAdminToken returns the token used to set up transit auto-unseal on the unsealing Vault
+func (r *ReconcileVault) transitAdminToken(ctx context.Context, v *vaultv1alpha1.Vault, unsealer *vaultv1alpha1.Vault) (string, error) {
+	if ref := v.Spec.UnsealConfig.Transit.TokenSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: ref.Name}, secret); err != nil {
+			return "", err
+		}
+		token, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	if unsealer.Spec.UnsealConfig.RevokesRootToken() {
+		return "", fmt.Errorf("the root token of the unsealing vault is revoked, set tokenSecretRef")
+	}
+
+	namespace, name, ok := unsealer.Spec.UnsealConfig.GetKubernetesSecret(unsealer)
+	if !ok {
+		return "", fmt.Errorf("the root token of the unsealing vault is not stored in a Kubernetes Secret, set tokenSecretRef")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return "", err
+	}
+	token, ok := secret.Data["vault-root"]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s holds no root token, set tokenSecretRef", namespace, name)
+	}
+	return string(token), nil
+}
+
+// vaultRolledOut checks if every Vault Pod runs with the current revision of the StatefulSet
+func (r *ReconcileVault) vaultRolledOut(ctx context.Context, v *vaultv1alpha1.Vault) bool {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, statefulSet); err != nil {
+		return false
+	}
+
+	return statefulSet.Status.ObservedGeneration == statefulSet.Generation &&
+		statefulSet.Status.UpdateRevision == statefulSet.Status.CurrentRevision &&
+		statefulSet.Status.ReadyReplicas == v.Spec.Size
+}
+
+// isInvalidAccessor checks if Vault refused to revoke a token accessor because the token doesn't exist anymore
+func isInvalidAccessor(err error) bool {
+	var responseError *api.ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode == http.StatusBadRequest || responseError.StatusCode == http.StatusNotFound
+	}
+	return strings.Contains(err.Error(), "invalid accessor")
+}
+
+func (r *ReconcileVault) setTransitUnsealStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.TransitUnsealStatus) error {
+	if v.Status.TransitUnseal != nil && v.Status.TransitUnseal.TokenAccessor == status.TokenAccessor &&
+		v.Status.TransitUnseal.TokenIssueTime.Equal(&status.TokenIssueTime) && v.Status.TransitUnseal.Message == status.Message {
+		return nil
+	}
+
+	if status.Message != "" {
+		r.recorder.Event(v, corev1.EventTypeWarning, "TransitUnsealFailed", status.Message)
+	}
+
+	v.Status.TransitUnseal = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.TransitUnseal = status
+	})
+}
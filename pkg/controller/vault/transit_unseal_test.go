@@ -0,0 +1,109 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransitUnseal(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-vault", Namespace: "tenant"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}}`),
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Transit: &vaultv1alpha1.TransitUnsealConfig{
+					VaultRef: vaultv1alpha1.TransitVaultRef{Name: "central-vault", Namespace: "default"},
+				},
+			},
+		},
+	}
+
+	assert.True(t, v.Spec.IsAutoUnseal())
+
+	configJSON, err := v.ConfigJSON()
+	require.NoError(t, err)
+	var config map[string]interface{}
+	require.NoError(t, json.Unmarshal(configJSON, &config))
+	assert.Equal(t, map[string]interface{}{
+		"transit": map[string]interface{}{
+			"address":     "${.Env.VAULT_TRANSIT_ADDRESS}",
+			"tls_ca_cert": "${.Env.VAULT_TRANSIT_CA_CERT}",
+			"mount_path":  "transit",
+			"key_name":    "tenant-tenant-vault",
+		},
+	}, config["seal"])
+
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+
+	vaultContainer := statefulSet.Spec.Template.Spec.Containers[0]
+	require.Equal(t, "vault", vaultContainer.Name)
+	assert.Contains(t, vaultContainer.Env, corev1.EnvVar{
+		Name: "VAULT_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "tenant-vault-transit-unseal"},
+				Key:                  transitTokenKey,
+			},
+		},
+	})
+	assert.Contains(t, vaultContainer.VolumeMounts, corev1.VolumeMount{Name: transitUnsealVolumeName, MountPath: transitUnsealMountPath})
+
+	// The address and CA certificate of the unsealing Vault
+	unsealer := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "central-vault", Namespace: "default"}}
+
+	reconciler, _ := newTestReconciler(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-vault-tls", Namespace: "default"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	})
+
+	data, err := reconciler.transitUnsealerData(context.Background(), unsealer)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		transitAddressKey:    []byte("https://central-vault.default:8200"),
+		transitCACertKey:     []byte("ca"),
+		transitCACertFileKey: []byte("/vault/transit-unseal/ca.crt"),
+	}, data)
+
+	// The revoked root token of the unsealing Vault is never the default
+	revoke := true
+	unsealer.Spec.UnsealConfig.Options.RevokeRootToken = &revoke
+	_, err = reconciler.transitAdminToken(context.Background(), v, unsealer)
+	assert.EqualError(t, err, "the root token of the unsealing vault is revoked, set tokenSecretRef")
+}
+
+func TestIsInvalidAccessor(t *testing.T) {
+	assert.True(t, isInvalidAccessor(&api.ResponseError{StatusCode: http.StatusBadRequest, Errors: []string{"1 error occurred:\n\t* invalid accessor\n\n"}}))
+	assert.True(t, isInvalidAccessor(&api.ResponseError{StatusCode: http.StatusNotFound}))
+	assert.False(t, isInvalidAccessor(&api.ResponseError{StatusCode: http.StatusForbidden, Errors: []string{"permission denied"}}))
+	assert.False(t, isInvalidAccessor(fmt.Errorf("connection refused")))
+	assert.True(t, isInvalidAccessor(fmt.Errorf("invalid accessor")))
+}
@@ -0,0 +1,455 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// unsealShareHolderLabel marks the Secrets holding the encrypted unseal key share of a key holder
+	unsealShareHolderLabel = "vault.banzaicloud.com/unseal-share-holder"
+
+	// unsealShareSubmissionLabel marks the Secrets holding unseal key shares submitted by the key holders,
+	// encrypted to the submission key of the operator
+	unsealShareSubmissionLabel = "vault.banzaicloud.com/unseal-share-submission"
+)
+
+// escrowInitBackoff retries storing the response of the initialization for about two minutes,
+// since it can't be retrieved again
+var escrowInitBackoff = wait.Backoff{Steps: 9, Duration: 500 * time.Millisecond, Factor: 2, Cap: 30 * time.Second}
+
+func unsealShareSecretName(v *vaultv1alpha1.Vault, holder string) string {
+	return v.Name + "-unseal-share-" + holder
+}
+
+func rootTokenSecretName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-root-token"
+}
+
+// escrowInitSecretName is the Secret holding the whole (PGP encrypted) response of the initialization,
+// until it is distributed to the Secrets of the key holders
+func escrowInitSecretName(v *vaultv1alpha1.Vault) string {
+	return v.Name + "-unseal-escrow-init"
+}
+
+// reconcileUnsealEscrow initializes Vault with PGP encrypted unseal key shares, joins the rest of the Pods to the
+// raft cluster, and unseals the sealed Vault Pods with the shares submitted by the key holders
+func (r *ReconcileVault) reconcileUnsealEscrow(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	escrow := v.Spec.UnsealConfig.Escrow
+
+	status := &vaultv1alpha1.UnsealEscrowStatus{
+		Threshold: escrow.GetThreshold(v.Spec.UnsealConfig.Options),
+	}
+	if v.Status.Escrow != nil {
+		status.Initialized = v.Status.Escrow.Initialized
+	}
+	for _, holder := range escrow.KeyHolders {
+		status.KeyHolders = append(status.KeyHolders, holder.Name)
+	}
+
+	if len(escrow.KeyHolders) == 0 || status.Threshold < 1 || status.Threshold > len(escrow.KeyHolders) {
+		status.Message = fmt.Sprintf("the threshold %d is not valid for %d key holders", status.Threshold, len(escrow.KeyHolders))
+		return r.setUnsealEscrowStatus(ctx, v, status)
+	}
+
+	// The shares of an initialization interrupted by an error are distributed first
+	if err := r.distributeEscrowShares(ctx, v); err != nil {
+		return err
+	}
+
+	pods := escrowSealStatuses(v)
+	for _, pod := range pods {
+		if pod.status != nil && pod.status.Initialized {
+			status.Initialized = true
+		}
+	}
+
+	unreachable := false
+	for i, pod := range pods {
+		if pod.status == nil {
+			unreachable = true
+			if i == 0 && !status.Initialized {
+				status.Message = fmt.Sprintf("waiting for %s to initialize vault", pod.name)
+			}
+			continue
+		}
+		if pod.status.Initialized {
+			if pod.status.Sealed {
+				status.SealedPods = append(status.SealedPods, pod.name)
+			}
+			continue
+		}
+
+		// The first Pod initializes the cluster, the rest of them join it once it is initialized,
+		// with a shared storage backend they are initialized through the first Pod
+		vaultClient, err := NewVaultClientForPod(ctx, r.client, v, pod.name)
+		if err != nil {
+			return err
+		}
+
+		if !status.Initialized {
+			if i > 0 {
+				continue
+			}
+			if err := r.initializeWithEscrow(ctx, v, vaultClient, status.Threshold); err != nil {
+				status.Message = err.Error()
+				r.recorder.Event(v, corev1.EventTypeWarning, "EscrowInitFailed", err.Error())
+				return r.setUnsealEscrowStatus(ctx, v, status)
+			}
+			status.Initialized = true
+		} else if v.Spec.IsRaftStorage() {
+			if err := r.joinRaftCluster(ctx, v, vaultClient); err != nil {
+				status.Message = fmt.Sprintf("failed to join %s to the raft cluster: %v", pod.name, err)
+				continue
+			}
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "RaftJoined", "%s joined the raft cluster at %s", pod.name, raftLeaderAPIAddr(v))
+		}
+		status.SealedPods = append(status.SealedPods, pod.name)
+	}
+
+	if escrow.ShareSubmission {
+		key, err := r.escrowSubmissionKey()
+		if err != nil {
+			return err
+		}
+		status.SubmissionPublicKey, err = submissionPublicKeyPEM(key)
+		if err != nil {
+			return err
+		}
+
+		shares, submissions, err := r.submittedUnsealShares(ctx, v, key)
+		if err != nil {
+			return err
+		}
+		status.SubmittedShares = len(shares)
+
+		if len(status.SealedPods) > 0 && len(shares) >= status.Threshold {
+			status.SealedPods, status.Message = r.unsealWithShares(ctx, v, status.SealedPods, shares)
+		}
+
+		// Submitted shares are kept only as long as they are needed
+		if len(status.SealedPods) == 0 && !unreachable && len(submissions) > 0 {
+			for i := range submissions {
+				if err := r.client.Delete(ctx, &submissions[i]); err != nil && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to delete unseal share submission %s: %v", submissions[i].Name, err)
+				}
+			}
+			status.SubmittedShares = 0
+			r.recorder.Event(v, corev1.EventTypeNormal, "UnsealSharesDeleted", "all Vault pods are unsealed, the submitted unseal shares have been deleted")
+		}
+	}
+
+	if len(status.SealedPods) > 0 && status.Message == "" {
+		status.Message = fmt.Sprintf("%d of %d unseal shares are needed to unseal the sealed pods", status.Threshold, len(escrow.KeyHolders))
+	}
+
+	return r.setUnsealEscrowStatus(ctx, v, status)
+}
+
+// initializeWithEscrow initializes Vault, and stores the PGP encrypted shares before anything else can fail
+func (r *ReconcileVault) initializeWithEscrow(ctx context.Context, v *vaultv1alpha1.Vault, vaultClient *api.Client, threshold int) error {
+	escrow := v.Spec.UnsealConfig.Escrow
+
+	request := &api.InitRequest{
+		SecretShares:    len(escrow.KeyHolders),
+		SecretThreshold: threshold,
+	}
+
+	for _, holder := range escrow.KeyHolders {
+		pgpKey, err := r.getPGPKey(ctx, v, holder.PGPKey)
+		if err != nil {
+			return fmt.Errorf("failed to get the pgp key of %s: %v", holder.Name, err)
+		}
+		request.PGPKeys = append(request.PGPKeys, pgpKey)
+	}
+
+	if escrow.RootTokenPGPKey != nil {
+		pgpKey, err := r.getPGPKey(ctx, v, *escrow.RootTokenPGPKey)
+		if err != nil {
+			return fmt.Errorf("failed to get the root token pgp key: %v", err)
+		}
+		request.RootTokenPGPKey = pgpKey
+	}
+
+	response, err := vaultClient.Sys().Init(request)
+	if err != nil {
+		return fmt.Errorf("failed to initialize vault: %v", err)
+	}
+
+	// The shares are returned in the order of the PGP keys, and can't be retrieved again, so the whole response
+	// is stored with a single write, and distributed to the key holders afterwards
+	data := map[string][]byte{}
+	for i, holder := range escrow.KeyHolders {
+		data["share-"+holder.Name] = []byte(response.KeysB64[i])
+	}
+	if escrow.RootTokenPGPKey != nil {
+		data["root-token"] = []byte(response.RootToken)
+	}
+
+	secret := escrowSecret(v, escrowInitSecretName(v), data)
+	err = retry.OnError(escrowInitBackoff, func(error) bool { return true }, func() error {
+		return r.createOrUpdateObject(ctx, secret.DeepCopy())
+	})
+	if err != nil {
+		// The shares can't be retrieved again, the caller reports this in the status and with an Event
+		log.Error(err, "failed to store the unseal shares of the initialization", "vault", v.Name,
+			"secret", secret.Name, "shares", len(escrow.KeyHolders))
+		return fmt.Errorf("vault is initialized, but its %d unseal shares couldn't be stored in secret %s/%s: %v",
+			len(escrow.KeyHolders), secret.Namespace, secret.Name, err)
+	}
+
+	if escrow.RootTokenPGPKey == nil {
+		vaultClient.SetToken(response.RootToken)
+		if err := vaultClient.Auth().Token().RevokeSelf(""); err != nil {
+			return fmt.Errorf("failed to revoke the initial root token: %v", err)
+		}
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "EscrowInitialized",
+		"vault initialized with %d PGP encrypted unseal shares, threshold %d", len(escrow.KeyHolders), threshold)
+
+	return r.distributeEscrowShares(ctx, v)
+}
+
+// distributeEscrowShares stores each PGP encrypted share of the initialization in the Secret of its key holder,
+// and the encrypted root token in its own Secret
+func (r *ReconcileVault) distributeEscrowShares(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	initSecret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: escrowInitSecretName(v)}, initSecret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get the unseal shares of the initialization: %v", err)
+	}
+
+	for _, holder := range v.Spec.UnsealConfig.Escrow.KeyHolders {
+		share, ok := initSecret.Data["share-"+holder.Name]
+		if !ok {
+			continue
+		}
+
+		secret := escrowSecret(v, unsealShareSecretName(v, holder.Name), map[string][]byte{"share": share})
+		secret.Labels[unsealShareHolderLabel] = holder.Name
+		if err := r.createOrUpdateObject(ctx, secret); err != nil {
+			return fmt.Errorf("failed to store the unseal share of %s: %v", holder.Name, err)
+		}
+	}
+
+	if token, ok := initSecret.Data["root-token"]; ok {
+		secret := escrowSecret(v, rootTokenSecretName(v), map[string][]byte{"token": token})
+		if err := r.createOrUpdateObject(ctx, secret); err != nil {
+			return fmt.Errorf("failed to store the encrypted root token: %v", err)
+		}
+	}
+
+	if err := r.client.Delete(ctx, initSecret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the unseal shares of the initialization: %v", err)
+	}
+
+	return nil
+}
+
+// escrowSecret returns a Secret for escrowed material, these are not owned by the Vault CR on purpose,
+// since they have to survive the deletion of the Vault cluster
+func escrowSecret(v *vaultv1alpha1.Vault, name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.Namespace,
+			Labels:    withVaultLabels(v, v.LabelsForVault()),
+		},
+		Data: data,
+	}
+}
+
+func (r *ReconcileVault) getPGPKey(ctx context.Context, v *vaultv1alpha1.Vault, pgpKey vaultv1alpha1.PGPKey) (string, error) {
+	if pgpKey.PublicKeySecretRef == nil {
+		if pgpKey.PublicKey == "" {
+			return "", fmt.Errorf("no public key specified")
+		}
+		return pgpKey.PublicKey, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: pgpKey.PublicKeySecretRef.Name}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := secret.Data[pgpKey.PublicKeySecretRef.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", secret.Name, pgpKey.PublicKeySecretRef.Key)
+	}
+
+	return strings.TrimSpace(string(key)), nil
+}
+
+// podSealStatus is the seal status of a Vault Pod, nil if it is not reachable
+type podSealStatus struct {
+	name   string
+	status *api.SealStatusResponse
+}
+
+// escrowSealStatuses returns the seal status of every Vault Pod
+func escrowSealStatuses(v *vaultv1alpha1.Vault) []podSealStatus {
+	var pods []podSealStatus
+	for i := 0; i < int(v.Spec.Size); i++ {
+		pod := podSealStatus{name: fmt.Sprintf("%s-%d", v.Name, i)}
+		if vaultClient, err := newInsecureVaultClientForPod(v, pod.name); err == nil {
+			// Not reachable yet, will be checked again in the next reconcile
+			pod.status, _ = vaultClient.Sys().SealStatus()
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// escrowSubmissionKey returns the key the key holders encrypt their unseal shares to. It only lives in the memory
+// of the operator, so the submissions can't be decrypted through the Kubernetes API, they are submitted again
+// after an operator restart.
+func (r *ReconcileVault) escrowSubmissionKey() (*rsa.PrivateKey, error) {
+	r.escrowKeyLock.Lock()
+	defer r.escrowKeyLock.Unlock()
+
+	if r.escrowKey == nil {
+		key, err := rsa.GenerateKey(rand.Reader, 3072)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the unseal share submission key: %v", err)
+		}
+		r.escrowKey = key
+	}
+
+	return r.escrowKey, nil
+}
+
+// submissionPublicKeyPEM returns the public submission key, as PEM encoded PKIX
+func submissionPublicKeyPEM(key *rsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// submittedUnsealShares returns the unseal shares submitted by the key holders, encrypted with RSA-OAEP (SHA-256)
+// to the submission key. Submissions encrypted to another key (e.g. before an operator restart) are deleted.
+func (r *ReconcileVault) submittedUnsealShares(ctx context.Context, v *vaultv1alpha1.Vault, key *rsa.PrivateKey) ([]string, []corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	listOps := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{unsealShareSubmissionLabel: v.Name}),
+		Namespace:     v.Namespace,
+	}
+	if err := r.client.List(ctx, secrets, listOps); err != nil {
+		return nil, nil, fmt.Errorf("failed to list unseal share submissions: %v", err)
+	}
+
+	var shares []string
+	var submissions []corev1.Secret
+	seen := map[string]bool{}
+	for _, secret := range secrets.Items {
+		decrypted, err := rsa.DecryptOAEP(sha256.New(), nil, key, secret.Data["share"], nil)
+		if err != nil {
+			if err := r.client.Delete(ctx, &secret); err != nil && !apierrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to delete unseal share submission %s: %v", secret.Name, err)
+			}
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "UnsealShareRejected",
+				"the unseal share in %s is not encrypted to the current submission key, it has been deleted, submit it again", secret.Name)
+			continue
+		}
+		submissions = append(submissions, secret)
+
+		share := strings.TrimSpace(string(decrypted))
+		if share != "" && !seen[share] {
+			shares = append(shares, share)
+			seen[share] = true
+		}
+	}
+
+	return shares, submissions, nil
+}
+
+// unsealWithShares unseals the given Vault Pods with the submitted shares, it returns the Pods which are still sealed
+func (r *ReconcileVault) unsealWithShares(ctx context.Context, v *vaultv1alpha1.Vault, pods []string, shares []string) ([]string, string) {
+	var stillSealed []string
+	message := ""
+
+	for _, podName := range pods {
+		vaultClient, err := NewVaultClientForPod(ctx, r.client, v, podName)
+		if err != nil {
+			stillSealed = append(stillSealed, podName)
+			continue
+		}
+
+		sealed := true
+		for _, share := range shares {
+			sealStatus, err := vaultClient.Sys().Unseal(share)
+			if err != nil {
+				message = fmt.Sprintf("failed to unseal %s: %v", podName, err)
+				break
+			}
+			if !sealStatus.Sealed {
+				sealed = false
+				break
+			}
+		}
+
+		if sealed {
+			// Don't leave a partial unseal progress behind
+			_, _ = vaultClient.Sys().ResetUnsealProcess()
+			stillSealed = append(stillSealed, podName)
+			if message == "" {
+				message = fmt.Sprintf("the submitted shares couldn't unseal %s", podName)
+			}
+			r.recorder.Event(v, corev1.EventTypeWarning, "UnsealFailed", message)
+			continue
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "Unsealed", "%s unsealed with the submitted unseal shares", podName)
+	}
+
+	return stillSealed, message
+}
+
+func (r *ReconcileVault) setUnsealEscrowStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.UnsealEscrowStatus) error {
+	if reflect.DeepEqual(status, v.Status.Escrow) {
+		return nil
+	}
+
+	v.Status.Escrow = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Escrow = status
+	})
+}
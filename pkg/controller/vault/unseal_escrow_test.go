@@ -0,0 +1,207 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestUnsealEscrow(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}}`),
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Escrow: &vaultv1alpha1.UnsealEscrow{
+					KeyHolders: []vaultv1alpha1.UnsealEscrowKeyHolder{
+						{Name: "alice", PGPKey: vaultv1alpha1.PGPKey{PublicKey: "keybase:alice"}},
+						{Name: "bob", PGPKey: vaultv1alpha1.PGPKey{PublicKeySecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "pgp-keys"},
+							Key:                  "bob.asc",
+						}}},
+					},
+					ShareSubmission: true,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 2, v.Spec.UnsealConfig.Escrow.GetThreshold(v.Spec.UnsealConfig.Options))
+
+	// Vault is initialized by the operator, and unsealed by the key holders instead of the sidecar
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		assert.NotEqual(t, unsealSidecarName, container.Name)
+	}
+
+	reconciler, c := newTestReconciler(
+		v,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgp-keys", Namespace: "default"},
+			Data:       map[string][]byte{"bob.asc": []byte("bXlrZXk=\n")},
+		},
+	)
+	key, err := reconciler.escrowSubmissionKey()
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	encrypt := func(key *rsa.PrivateKey, share string) []byte {
+		encrypted, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte(share), nil)
+		require.NoError(t, err)
+		return encrypted
+	}
+
+	for _, submission := range []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-share", Namespace: "default", Labels: map[string]string{unsealShareSubmissionLabel: "vault"}},
+			Data:       map[string][]byte{"share": encrypt(key, "share-1")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-share-again", Namespace: "default", Labels: map[string]string{unsealShareSubmissionLabel: "vault"}},
+			Data:       map[string][]byte{"share": encrypt(key, "share-1\n")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob-share", Namespace: "default", Labels: map[string]string{unsealShareSubmissionLabel: "vault"}},
+			Data:       map[string][]byte{"share": encrypt(otherKey, "share-2")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-share", Namespace: "default", Labels: map[string]string{unsealShareSubmissionLabel: "other"}},
+			Data:       map[string][]byte{"share": encrypt(key, "share-3")},
+		},
+	} {
+		require.NoError(t, c.Create(ctx, submission))
+	}
+
+	pgpKey, err := reconciler.getPGPKey(ctx, v, v.Spec.UnsealConfig.Escrow.KeyHolders[1].PGPKey)
+	require.NoError(t, err)
+	assert.Equal(t, "bXlrZXk=", pgpKey)
+
+	// The same share submitted twice counts only once, a share encrypted to another key is deleted
+	shares, submissions, err := reconciler.submittedUnsealShares(ctx, v, key)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"share-1"}, shares)
+	assert.Len(t, submissions, 2)
+	err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "bob-share"}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// The response of an interrupted initialization is distributed to the key holders
+	require.NoError(t, c.Create(ctx, escrowSecret(v, escrowInitSecretName(v), map[string][]byte{
+		"share-alice": []byte("encrypted-1"),
+		"share-bob":   []byte("encrypted-2"),
+		"root-token":  []byte("encrypted-token"),
+	})))
+	require.NoError(t, reconciler.distributeEscrowShares(ctx, v))
+
+	share := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-unseal-share-bob"}, share))
+	assert.Equal(t, []byte("encrypted-2"), share.Data["share"])
+	assert.Equal(t, "bob", share.Labels[unsealShareHolderLabel])
+	token := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-root-token"}, token))
+	assert.Equal(t, []byte("encrypted-token"), token.Data["token"])
+	err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: escrowInitSecretName(v)}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// An initialized Vault stays initialized while its Pods are unreachable
+	v.Status.Escrow = &vaultv1alpha1.UnsealEscrowStatus{Initialized: true}
+	require.NoError(t, reconciler.reconcileUnsealEscrow(ctx, v))
+	assert.True(t, v.Status.Escrow.Initialized)
+	assert.Contains(t, v.Status.Escrow.SubmissionPublicKey, "BEGIN PUBLIC KEY")
+
+	// The submitted shares are kept until the Pods are known to be unsealed
+	assert.Equal(t, 1, v.Status.Escrow.SubmittedShares)
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "alice-share"}, &corev1.Secret{}))
+}
+
+func TestUnsealEscrowInitStoreFailure(t *testing.T) {
+	ctx := context.Background()
+
+	fakeVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/sys/init":
+			_, _ = w.Write([]byte(`{"keys": ["encrypted-1", "encrypted-2"], "keys_base64": ["encrypted-1", "encrypted-2"], "root_token": "root"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer fakeVault.Close()
+
+	podAddress := vaultPodAddress
+	vaultPodAddress = func(*vaultv1alpha1.Vault, string) string { return fakeVault.URL }
+	defer func() { vaultPodAddress = podAddress }()
+
+	backoff := escrowInitBackoff
+	escrowInitBackoff = wait.Backoff{Steps: 1}
+	defer func() { escrowInitBackoff = backoff }()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`),
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Escrow: &vaultv1alpha1.UnsealEscrow{
+					KeyHolders: []vaultv1alpha1.UnsealEscrowKeyHolder{
+						{Name: "alice", PGPKey: vaultv1alpha1.PGPKey{PublicKey: "YWxpY2U="}},
+						{Name: "bob", PGPKey: vaultv1alpha1.PGPKey{PublicKey: "Ym9i"}},
+					},
+				},
+			},
+		},
+	}
+
+	reconciler, c := newTestReconciler(v)
+	reconciler.client = interceptor.NewClient(c.(client.WithWatch), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return fmt.Errorf("etcd is unavailable")
+		},
+	})
+	vaultClient, err := NewVaultClientForPod(ctx, reconciler.client, v, "vault-0")
+	require.NoError(t, err)
+
+	// The shares are lost, the error tells where they should have been stored without revealing them
+	err = reconciler.initializeWithEscrow(ctx, v, vaultClient, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 unseal shares")
+	assert.Contains(t, err.Error(), "default/vault-unseal-escrow-init")
+	assert.NotContains(t, err.Error(), "encrypted-1")
+}
@@ -0,0 +1,220 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unsealKeysHashAnnotation holds the hash of the unseal keys a mirror has been written with by the operator,
+// a mirror which doesn't match its own hash anymore has been changed by someone else
+const unsealKeysHashAnnotation = "vault.banzaicloud.io/unseal-keys-hash"
+
+// unsealKeysHash returns a stable hash of the Secret data
+func unsealKeysHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// reconcileUnsealKeyMirrors copies the unseal keys from the primary backend into the mirrors,
+// and records the consistency of each mirror in the status
+func (r *ReconcileVault) reconcileUnsealKeyMirrors(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	var statuses []vaultv1alpha1.UnsealKeyMirrorStatus
+
+	primaryNamespace, primaryName, _ := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+
+	primaryMessage := ""
+	primary, err := r.primaryUnsealKeys(ctx, v)
+	if errors.Is(err, errUnsealKeyStoreUnsupported) {
+		primaryMessage = err.Error()
+	} else if err != nil {
+		primaryMessage = fmt.Sprintf("failed to read the unseal keys from the primary backend: %v", err)
+	}
+
+	for _, mirror := range v.Spec.UnsealConfig.Mirrors {
+		status := vaultv1alpha1.UnsealKeyMirrorStatus{
+			SecretNamespace: mirror.SecretNamespace,
+			SecretName:      mirror.SecretName,
+		}
+		if status.SecretNamespace == "" {
+			status.SecretNamespace = v.Namespace
+		}
+
+		switch {
+		case primaryMessage != "":
+			status.State = vaultv1alpha1.UnsealKeyMirrorFailed
+			status.Message = primaryMessage
+		case len(primary) == 0:
+			status.State = vaultv1alpha1.UnsealKeyMirrorPending
+			status.Message = "the primary backend holds no unseal keys yet"
+		case status.SecretName == "" || (status.SecretNamespace == primaryNamespace && status.SecretName == primaryName):
+			status.State = vaultv1alpha1.UnsealKeyMirrorFailed
+			status.Message = "the mirror must be a different Secret than the primary one"
+		default:
+			status.State, status.Message = r.syncUnsealKeyMirror(ctx, v, primary, status.SecretNamespace, status.SecretName)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return r.setUnsealKeyMirrorsStatus(ctx, v, statuses)
+}
+
+// primaryUnsealKeys reads the unseal keys from the Kubernetes Secret or the AWS KMS encrypted S3 objects
+// of the primary backend, the other backends are not supported
+func (r *ReconcileVault) primaryUnsealKeys(ctx context.Context, v *vaultv1alpha1.Vault) (map[string][]byte, error) {
+	usc := v.Spec.UnsealConfig
+
+	if namespace, name, ok := usc.GetKubernetesSecret(v); ok {
+		primary := &corev1.Secret{}
+		err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, primary)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get unseal keys secret: %v", err)
+		}
+		return primary.Data, nil
+	}
+
+	mode := unsealMode(v)
+	if mode != "aws-kms-s3" {
+		return nil, fmt.Errorf("%w: unseal key mirrors support the k8s, hsm-k8s and aws-kms-s3 primary backends, not %s",
+			errUnsealKeyStoreUnsupported, mode)
+	}
+
+	store, err := newAWSUnsealKeyStore(ctx, usc.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	return readUnsealKeys(ctx, store, usc.Options)
+}
+
+// unsealMode returns the bank-vaults keystore mode selected by the UnsealConfig
+func unsealMode(v *vaultv1alpha1.Vault) string {
+	args := v.Spec.UnsealConfig.ToArgs(v)
+	for i, arg := range args {
+		if arg == "--mode" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// syncUnsealKeyMirror writes the unseal keys into a single mirror, unless the mirror has diverged
+func (r *ReconcileVault) syncUnsealKeyMirror(ctx context.Context, v *vaultv1alpha1.Vault, primary map[string][]byte, namespace, name string) (vaultv1alpha1.UnsealKeyMirrorState, string) {
+	primaryHash := unsealKeysHash(primary)
+
+	mirror := &corev1.Secret{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, mirror)
+	if apierrors.IsNotFound(err) {
+		// The mirror is not owned by the Vault CR on purpose, it has to survive the deletion of the Vault cluster
+		mirror = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      withVaultLabels(v, v.LabelsForVault()),
+				Annotations: map[string]string{unsealKeysHashAnnotation: primaryHash},
+			},
+			Data: primary,
+		}
+
+		if err := r.nonNamespacedClient.Create(ctx, mirror); err != nil {
+			return vaultv1alpha1.UnsealKeyMirrorFailed, fmt.Sprintf("failed to create mirror: %v", err)
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "UnsealKeysMirrored", "unseal keys copied to %s/%s", namespace, name)
+		return vaultv1alpha1.UnsealKeyMirrorInSync, ""
+	} else if err != nil {
+		return vaultv1alpha1.UnsealKeyMirrorFailed, fmt.Sprintf("failed to get mirror: %v", err)
+	}
+
+	mirrorHash := unsealKeysHash(mirror.Data)
+	if mirrorHash == primaryHash {
+		return vaultv1alpha1.UnsealKeyMirrorInSync, ""
+	}
+
+	// The mirror still holds what the operator has written into it, so the primary has changed (e.g. rekey)
+	if mirrorHash == mirror.Annotations[unsealKeysHashAnnotation] {
+		mirror.Data = primary
+		mirror.Annotations[unsealKeysHashAnnotation] = primaryHash
+
+		if err := r.nonNamespacedClient.Update(ctx, mirror); err != nil {
+			return vaultv1alpha1.UnsealKeyMirrorFailed, fmt.Sprintf("failed to update mirror: %v", err)
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "UnsealKeysMirrored", "unseal keys updated in %s/%s", namespace, name)
+		return vaultv1alpha1.UnsealKeyMirrorInSync, ""
+	}
+
+	return vaultv1alpha1.UnsealKeyMirrorDiverged, "the mirror has been modified outside of the operator, resolve the conflict manually"
+}
+
+// setUnsealKeyMirrorsStatus stores the mirror statuses, and emits an Event for the mirrors which have diverged
+func (r *ReconcileVault) setUnsealKeyMirrorsStatus(ctx context.Context, v *vaultv1alpha1.Vault, statuses []vaultv1alpha1.UnsealKeyMirrorStatus) error {
+	previous := map[string]vaultv1alpha1.UnsealKeyMirrorStatus{}
+	for _, status := range v.Status.UnsealKeyMirrors {
+		previous[status.SecretNamespace+"/"+status.SecretName] = status
+	}
+
+	now := metav1.Now()
+	for i := range statuses {
+		status := &statuses[i]
+		old, ok := previous[status.SecretNamespace+"/"+status.SecretName]
+		if ok && old.State == status.State && old.Message == status.Message {
+			status.LastTransitionTime = old.LastTransitionTime
+			continue
+		}
+
+		status.LastTransitionTime = now
+		if status.State == vaultv1alpha1.UnsealKeyMirrorDiverged || status.State == vaultv1alpha1.UnsealKeyMirrorFailed {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "UnsealKeyMirror"+string(status.State), "%s/%s: %s",
+				status.SecretNamespace, status.SecretName, status.Message)
+		}
+	}
+
+	if reflect.DeepEqual(statuses, v.Status.UnsealKeyMirrors) {
+		return nil
+	}
+
+	v.Status.UnsealKeyMirrors = statuses
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.UnsealKeyMirrors = statuses
+	})
+}
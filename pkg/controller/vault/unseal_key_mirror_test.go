@@ -0,0 +1,165 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileUnsealKeyMirrors(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Mirrors: []vaultv1alpha1.KubernetesUnsealConfig{
+					{SecretNamespace: "break-glass", SecretName: "vault-unseal-keys"},
+				},
+			},
+		},
+	}
+	primary := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data:       map[string][]byte{"vault-root": []byte("root"), "vault-unseal-0": []byte("key")},
+	}
+
+	reconciler, c := newTestReconciler(v, primary)
+
+	require.NoError(t, reconciler.reconcileUnsealKeyMirrors(ctx, v))
+	require.Len(t, v.Status.UnsealKeyMirrors, 1)
+	assert.Equal(t, vaultv1alpha1.UnsealKeyMirrorInSync, v.Status.UnsealKeyMirrors[0].State)
+
+	mirror := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "break-glass", Name: "vault-unseal-keys"}, mirror))
+	assert.Equal(t, primary.Data, mirror.Data)
+
+	// A change of the primary is propagated
+	primary.Data["vault-unseal-0"] = []byte("rekeyed")
+	require.NoError(t, c.Update(ctx, primary))
+	require.NoError(t, reconciler.reconcileUnsealKeyMirrors(ctx, v))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(mirror), mirror))
+	assert.Equal(t, []byte("rekeyed"), mirror.Data["vault-unseal-0"])
+
+	// A change of the mirror is reported, and not overwritten
+	mirror.Data["vault-unseal-0"] = []byte("tampered")
+	require.NoError(t, c.Update(ctx, mirror))
+	require.NoError(t, reconciler.reconcileUnsealKeyMirrors(ctx, v))
+	assert.Equal(t, vaultv1alpha1.UnsealKeyMirrorDiverged, v.Status.UnsealKeyMirrors[0].State)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(mirror), mirror))
+	assert.Equal(t, []byte("tampered"), mirror.Data["vault-unseal-0"])
+}
+
+func TestReconcileUnsealKeyMirrorsUnsupportedPrimary(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				Google: &vaultv1alpha1.GoogleUnsealConfig{StorageBucket: "keys"},
+				Mirrors: []vaultv1alpha1.KubernetesUnsealConfig{
+					{SecretNamespace: "break-glass", SecretName: "vault-unseal-keys"},
+				},
+			},
+		},
+	}
+
+	reconciler, _ := newTestReconciler(v)
+
+	require.NoError(t, reconciler.reconcileUnsealKeyMirrors(ctx, v))
+	require.Len(t, v.Status.UnsealKeyMirrors, 1)
+	assert.Equal(t, vaultv1alpha1.UnsealKeyMirrorFailed, v.Status.UnsealKeyMirrors[0].State)
+	assert.Contains(t, v.Status.UnsealKeyMirrors[0].Message, "google-cloud-kms-gcs")
+}
+
+type fakeKMS struct {
+	encryptionContext map[string]string
+}
+
+func (f *fakeKMS) Decrypt(_ context.Context, params *kms.DecryptInput, _ ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	if !reflect.DeepEqual(params.EncryptionContext, f.encryptionContext) {
+		return nil, errors.New("invalid encryption context")
+	}
+	return &kms.DecryptOutput{Plaintext: bytes.TrimPrefix(params.CiphertextBlob, []byte("encrypted:"))}, nil
+}
+
+type fakeS3 map[string]string
+
+func (f fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	object, ok := f[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("encrypted:" + object))}, nil
+}
+
+func TestReadUnsealKeysFromAWS(t *testing.T) {
+	ctx := context.Background()
+
+	encryptionContext, err := parseKMSEncryptionContext("team=platform,env=prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "prod"}, encryptionContext)
+
+	objects := fakeS3{}
+	store := &awsUnsealKeyStore{
+		kms:               &fakeKMS{encryptionContext: encryptionContext},
+		s3:                objects,
+		bucket:            "keys",
+		prefix:            "vault/",
+		encryptionContext: encryptionContext,
+	}
+
+	shares := uint(2)
+	options := vaultv1alpha1.UnsealOptions{SecretShares: &shares}
+
+	// Nothing is stored before Vault is initialized
+	data, err := readUnsealKeys(ctx, store, options)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	objects["keys/vault/vault-root"] = "root"
+	objects["keys/vault/vault-unseal-0"] = "key-0"
+	objects["keys/vault/vault-unseal-1"] = "key-1"
+
+	data, err = readUnsealKeys(ctx, store, options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"vault-root":     []byte("root"),
+		"vault-unseal-0": []byte("key-0"),
+		"vault-unseal-1": []byte("key-1"),
+	}, data)
+
+	// The keys can't be decrypted without the encryption context they were encrypted with
+	store.encryptionContext = nil
+	_, err = readUnsealKeys(ctx, store, options)
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+)
+
+// errUnsealKeyStoreUnsupported is returned for primary backends the operator can't read the unseal keys from
+var errUnsealKeyStoreUnsupported = errors.New("unsupported unseal key backend")
+
+// unsealKeyStore reads the keys bank-vaults has written into a keystore backend
+type unsealKeyStore interface {
+	// Get returns nil without an error if the key doesn't exist
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// readUnsealKeys reads the unseal (or recovery) keys, the root token and the pre-flight test key from the keystore,
+// returns nil if the keystore holds no unseal keys yet
+func readUnsealKeys(ctx context.Context, store unsealKeyStore, options vaultv1alpha1.UnsealOptions) (map[string][]byte, error) {
+	keys := []string{"vault-root", "vault-test"}
+	for i := range options.GetSecretShares() {
+		keys = append(keys, fmt.Sprintf("%s%d", unsealKeyPrefix, i), fmt.Sprintf("%s%d", recoveryKeyPrefix, i))
+	}
+
+	data := map[string][]byte{}
+	for _, key := range keys {
+		value, err := store.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		if value != nil {
+			data[key] = value
+		}
+	}
+
+	_, unsealKeys := data[unsealKeyPrefix+"0"]
+	_, recoveryKeys := data[recoveryKeyPrefix+"0"]
+	if !unsealKeys && !recoveryKeys {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// kmsDecrypter is the part of the AWS KMS API used to read the unseal keys
+type kmsDecrypter interface {
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// s3ObjectGetter is the part of the AWS S3 API used to read the unseal keys
+type s3ObjectGetter interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// awsUnsealKeyStore reads the keys bank-vaults stores KMS encrypted in S3 (aws-kms-s3 mode)
+type awsUnsealKeyStore struct {
+	kms               kmsDecrypter
+	s3                s3ObjectGetter
+	bucket            string
+	prefix            string
+	encryptionContext map[string]string
+}
+
+// newAWSUnsealKeyStore creates a keystore for the aws-kms-s3 mode, with the credentials of the operator.
+// Only the first of the comma separated keys and buckets is read, bank-vaults writes the same keys into all of them.
+func newAWSUnsealKeyStore(ctx context.Context, config *vaultv1alpha1.AWSUnsealConfig) (*awsUnsealKeyStore, error) {
+	encryptionContext, err := parseKMSEncryptionContext(config.KMSEncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	kmsRegion := firstOf(config.KMSRegion)
+	s3Region := firstOf(config.S3Region)
+
+	return &awsUnsealKeyStore{
+		kms: kms.NewFromConfig(awsConfig, func(o *kms.Options) {
+			if kmsRegion != "" {
+				o.Region = kmsRegion
+			}
+		}),
+		s3: s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+			if s3Region != "" {
+				o.Region = s3Region
+			}
+		}),
+		bucket:            firstOf(config.S3Bucket),
+		prefix:            firstOf(config.S3Prefix),
+		encryptionContext: encryptionContext,
+	}, nil
+}
+
+func (store *awsUnsealKeyStore) Get(ctx context.Context, key string) ([]byte, error) {
+	object, err := store.s3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get object from S3: %w", err)
+	}
+	defer object.Body.Close()
+
+	ciphertext, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object from S3: %w", err)
+	}
+
+	decrypted, err := store.kms.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob:    ciphertext,
+		EncryptionContext: store.encryptionContext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with KMS: %w", err)
+	}
+
+	return decrypted.Plaintext, nil
+}
+
+// parseKMSEncryptionContext parses the key1=value1,key2=value2 format of the bank-vaults flag
+func parseKMSEncryptionContext(encryptionContext string) (map[string]string, error) {
+	if encryptionContext == "" {
+		return nil, nil
+	}
+
+	parsed := map[string]string{}
+	for _, pair := range strings.Split(encryptionContext, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid KMS encryption context: %q", encryptionContext)
+		}
+		parsed[key] = value
+	}
+
+	return parsed, nil
+}
+
+// firstOf returns the first element of a comma separated list
+func firstOf(list string) string {
+	first, _, _ := strings.Cut(list, ",")
+	return first
+}
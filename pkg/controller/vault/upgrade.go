@@ -0,0 +1,446 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const snapshotMountPath = "/snapshots"
+
+// isUpgrading checks if an orchestrated upgrade is in progress
+func isUpgrading(v *vaultv1alpha1.Vault) bool {
+	if v.Status.Upgrade == nil {
+		return false
+	}
+	switch v.Status.Upgrade.Phase {
+	case vaultv1alpha1.UpgradeSnapshotting, vaultv1alpha1.UpgradeRollingOut, vaultv1alpha1.UpgradePaused:
+		return true
+	}
+	return false
+}
+
+// vaultContainerImage returns the image of the Vault container in the StatefulSet
+func vaultContainerImage(statefulSet *appsv1.StatefulSet) string {
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		if container.Name == "vault" {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// partitionApplied checks if the StatefulSet controller has observed the given partition
+func partitionApplied(statefulSet *appsv1.StatefulSet, partition int32) bool {
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	return statefulSet.Status.ObservedGeneration == statefulSet.Generation &&
+		rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition == partition
+}
+
+// onDeleteApplied checks if the StatefulSet controller has observed the OnDelete update strategy,
+// from then on the deleted Pods are recreated with the update revision
+func onDeleteApplied(statefulSet *appsv1.StatefulSet) bool {
+	return statefulSet.Status.ObservedGeneration == statefulSet.Generation &&
+		statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType
+}
+
+// detectUpgrade starts an upgrade if the Vault image has changed, during the upgrade the StatefulSet has to use
+// the OnDelete update strategy. It has to run before the StatefulSet picks up the new image.
+func (r *ReconcileVault) detectUpgrade(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	toImage := v.Spec.GetVaultImage()
+	if isUpgrading(v) && v.Status.Upgrade.ToImage == toImage {
+		return nil
+	}
+
+	current := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, current)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	fromImage := vaultContainerImage(current)
+	if !isUpgrading(v) && (fromImage == "" || fromImage == toImage) {
+		return nil
+	}
+
+	status := &vaultv1alpha1.UpgradeStatus{
+		Phase:     vaultv1alpha1.UpgradeRollingOut,
+		FromImage: fromImage,
+		ToImage:   toImage,
+	}
+
+	if isUpgrading(v) {
+		// The image has changed again during the upgrade, the rollout starts over with the same snapshot
+		status.FromImage = v.Status.Upgrade.FromImage
+		status.SnapshotJob = v.Status.Upgrade.SnapshotJob
+		if v.Status.Upgrade.Phase == vaultv1alpha1.UpgradeSnapshotting {
+			status.Phase = vaultv1alpha1.UpgradeSnapshotting
+		}
+	} else if v.Spec.UpgradeStrategy.Snapshot != nil {
+		if v.Spec.IsRaftStorage() {
+			status.Phase = vaultv1alpha1.UpgradeSnapshotting
+		} else {
+			r.recorder.Event(v, corev1.EventTypeWarning, "UpgradeSnapshotSkipped", "snapshots are only supported with raft storage")
+		}
+	}
+
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "UpgradeStarted", "upgrading vault from %s to %s", status.FromImage, status.ToImage)
+
+	return r.setUpgradeStatus(ctx, v, status)
+}
+
+// reconcileUpgrade drives an orchestrated upgrade one step forward, it returns true until the upgrade is completed.
+// The StatefulSet only updates the Pods deleted here: the standbys first from the highest ordinal down,
+// then the active node, once it has stepped down.
+func (r *ReconcileVault) reconcileUpgrade(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	status := v.Status.Upgrade.DeepCopy()
+
+	if status.Phase == vaultv1alpha1.UpgradeSnapshotting {
+		saved, err := r.reconcileUpgradeSnapshot(ctx, v, status)
+		if err != nil {
+			return false, err
+		}
+		if !saved {
+			return true, r.setUpgradeStatus(ctx, v, status)
+		}
+
+		status.Phase = vaultv1alpha1.UpgradeRollingOut
+		status.Message = ""
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "UpgradeSnapshotSaved", "raft snapshot saved by job %s", status.SnapshotJob)
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, statefulSet); err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The StatefulSet controller has to pick up the new image and the update strategy first
+	if !onDeleteApplied(statefulSet) {
+		return true, r.setUpgradeStatus(ctx, v, status)
+	}
+
+	if status.Pod != "" {
+		if err := r.updatedPodHealthy(ctx, v, status.Pod, statefulSet.Status.UpdateRevision); err != nil {
+			if status.Phase != vaultv1alpha1.UpgradePaused && status.StepStartTime != nil &&
+				time.Since(status.StepStartTime.Time) > v.Spec.UpgradeStrategy.GetHealthTimeout() {
+				status.Phase = vaultv1alpha1.UpgradePaused
+				status.Message = fmt.Sprintf("%s is not healthy after %s: %v", status.Pod, v.Spec.UpgradeStrategy.GetHealthTimeout(), err)
+				r.recorder.Event(v, corev1.EventTypeWarning, "UpgradePaused", status.Message)
+			}
+			return true, r.setUpgradeStatus(ctx, v, status)
+		}
+
+		if status.Phase == vaultv1alpha1.UpgradePaused {
+			status.Phase = vaultv1alpha1.UpgradeRollingOut
+			status.Message = ""
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "UpgradeResumed", "%s is healthy, the upgrade continues", status.Pod)
+		}
+	}
+
+	podName, active, err := r.nextUpgradePod(ctx, v, statefulSet.Status.UpdateRevision)
+	if err != nil {
+		status.Message = err.Error()
+		return true, r.setUpgradeStatus(ctx, v, status)
+	}
+
+	if podName == "" {
+		status.Phase = vaultv1alpha1.UpgradeCompleted
+		status.Pod = ""
+		status.StepStartTime = nil
+		status.Message = ""
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "UpgradeCompleted", "every vault pod runs %s", status.ToImage)
+		return false, r.setUpgradeStatus(ctx, v, status)
+	}
+
+	// The active node hands over the leadership to an updated standby before it is updated
+	if active {
+		steppedDown, err := r.stepDownIfActive(ctx, v, podName)
+		if err != nil {
+			status.Message = fmt.Sprintf("failed to step down %s: %v", podName, err)
+			return true, r.setUpgradeStatus(ctx, v, status)
+		}
+		if steppedDown {
+			r.recorder.Eventf(v, corev1.EventTypeNormal, "LeaderSteppedDown", "%s stepped down before it is updated", podName)
+			return true, r.setUpgradeStatus(ctx, v, status)
+		}
+	}
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: v.Namespace, Name: podName}}
+	if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete pod %s: %v", podName, err)
+	}
+
+	status.Pod = podName
+	status.StepStartTime = ptr.To(metav1.Now())
+	status.Message = ""
+	r.recorder.Eventf(v, corev1.EventTypeNormal, "UpgradeStep", "updating %s to %s", podName, status.ToImage)
+
+	return true, r.setUpgradeStatus(ctx, v, status)
+}
+
+// nextUpgradePod returns the next Vault Pod to update, and whether it is the active node. The standbys which don't
+// run the update revision go first from the highest ordinal down, the active node last. It returns no Pod once
+// every Pod runs the update revision.
+func (r *ReconcileVault) nextUpgradePod(ctx context.Context, v *vaultv1alpha1.Vault, revision string) (string, bool, error) {
+	active := ""
+	for i := int(v.Spec.Size) - 1; i >= 0; i-- {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+
+		pod := &corev1.Pod{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, pod); err != nil {
+			return "", false, fmt.Errorf("failed to get pod %s: %v", podName, err)
+		}
+		if pod.Labels[appsv1.StatefulSetRevisionLabel] == revision {
+			continue
+		}
+
+		vaultClient, err := newInsecureVaultClientForPod(v, podName)
+		if err != nil {
+			return "", false, err
+		}
+		health, err := vaultClient.Sys().Health()
+		if err == nil && health.Initialized && !health.Sealed && !health.Standby {
+			active = podName
+			continue
+		}
+
+		return podName, false, nil
+	}
+
+	return active, active != "", nil
+}
+
+// updatedPodHealthy checks if the Vault Pod runs the update revision of the StatefulSet, and is unsealed
+func (r *ReconcileVault) updatedPodHealthy(ctx context.Context, v *vaultv1alpha1.Vault, podName, revision string) error {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: podName}, pod); err != nil {
+		return err
+	}
+	if pod.Labels[appsv1.StatefulSetRevisionLabel] != revision {
+		return fmt.Errorf("not updated yet")
+	}
+
+	vaultClient, err := newInsecureVaultClientForPod(v, podName)
+	if err != nil {
+		return err
+	}
+
+	health, err := vaultClient.Sys().Health()
+	if err != nil {
+		return err
+	}
+	if !health.Initialized {
+		return fmt.Errorf("not initialized")
+	}
+	if health.Sealed {
+		return fmt.Errorf("sealed")
+	}
+	return nil
+}
+
+// stepDownIfActive steps down the Vault Pod if it is the active node of a cluster with standbys,
+// it returns true if the Pod has been stepped down
+func (r *ReconcileVault) stepDownIfActive(ctx context.Context, v *vaultv1alpha1.Vault, podName string) (bool, error) {
+	if v.Spec.Size < 2 {
+		return false, nil
+	}
+
+	healthClient, err := newInsecureVaultClientForPod(v, podName)
+	if err != nil {
+		return false, err
+	}
+
+	health, err := healthClient.Sys().Health()
+	if err != nil || !health.Initialized || health.Sealed || health.Standby {
+		return false, nil
+	}
+
+	vaultClient, err := NewVaultClientForPod(ctx, r.client, v, podName)
+	if err != nil {
+		return false, err
+	}
+
+	var tokenRef *corev1.SecretKeySelector
+	if v.Spec.UpgradeStrategy != nil {
+		tokenRef = v.Spec.UpgradeStrategy.TokenSecretRef
+	}
+	token, err := r.vaultToken(ctx, v, tokenRef)
+	if err != nil {
+		return false, err
+	}
+	vaultClient.SetToken(token)
+
+	if err := vaultClient.Sys().StepDown(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reconcileUpgradeSnapshot saves a raft snapshot with a Job, it returns true once the Job has completed.
+// A failed Job has to be deleted to retry the snapshot.
+func (r *ReconcileVault) reconcileUpgradeSnapshot(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.UpgradeStatus) (bool, error) {
+	if status.SnapshotJob == "" {
+		job, err := snapshotJobForVault(v, status.FromImage)
+		if err != nil {
+			status.Message = err.Error()
+			return false, nil
+		}
+		if err := controllerutil.SetControllerReference(v, job, r.scheme); err != nil {
+			return false, err
+		}
+		if err := r.client.Create(ctx, job); err != nil {
+			return false, fmt.Errorf("failed to create snapshot job: %v", err)
+		}
+		status.SnapshotJob = job.Name
+		status.Message = ""
+		return false, nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: status.SnapshotJob}, job)
+	if apierrors.IsNotFound(err) {
+		status.SnapshotJob = ""
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get snapshot job: %v", err)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			message := fmt.Sprintf("snapshot job %s failed: %s, delete it to retry", job.Name, condition.Message)
+			if status.Message != message {
+				status.Message = message
+				r.recorder.Event(v, corev1.EventTypeWarning, "UpgradeSnapshotFailed", message)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// snapshotJobLabels returns the labels of the snapshot Job and its Pod, which must not match the selectors
+// of the Vault Services and Pods, otherwise clients would be sent to the snapshot Pod
+func snapshotJobLabels(v *vaultv1alpha1.Vault) map[string]string {
+	return map[string]string{"vault.banzaicloud.com/upgrade-snapshot": v.Name}
+}
+
+// snapshotJobForVault returns a Job which saves a raft snapshot of Vault onto the snapshot volume
+func snapshotJobForVault(v *vaultv1alpha1.Vault, image string) (*batchv1.Job, error) {
+	tokenRef := v.Spec.UpgradeStrategy.TokenSecretRef
+	if tokenRef == nil {
+		if v.Spec.UnsealConfig.RevokesRootToken() {
+			return nil, fmt.Errorf("the root token is revoked, set tokenSecretRef")
+		}
+
+		namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+		if !ok || namespace != v.Namespace {
+			return nil, fmt.Errorf("the root token is not stored in a Kubernetes Secret in the namespace of the vault, set tokenSecretRef")
+		}
+		tokenRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  "vault-root",
+		}
+	}
+
+	now := time.Now().UTC().Format("20060102150405")
+	snapshotFile := fmt.Sprintf("%s/%s-%s.snap", snapshotMountPath, v.Name, now)
+
+	volumes := withTLSVolume(v, []corev1.Volume{{
+		Name: "snapshots",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: v.Spec.UpgradeStrategy.Snapshot.PersistentVolumeClaimName,
+			},
+		},
+	}})
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-snapshot-%s", v.Name, now),
+			Namespace:   v.Namespace,
+			Labels:      snapshotJobLabels(v),
+			Annotations: getCommonAnnotations(v, map[string]string{}),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: snapshotJobLabels(v),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: v.Spec.GetServiceAccount(),
+					SecurityContext:    withPodSecurityContext(v),
+					Volumes:            volumes,
+					Containers: []corev1.Container{{
+						Name:            "snapshot",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"vault", "operator", "raft", "snapshot", "save", snapshotFile},
+						Env: withTLSEnv(v, false, []corev1.EnvVar{{
+							Name:      "VAULT_TOKEN",
+							ValueFrom: &corev1.EnvVarSource{SecretKeyRef: tokenRef},
+						}}),
+						VolumeMounts: withTLSVolumeMount(v, []corev1.VolumeMount{{
+							Name:      "snapshots",
+							MountPath: snapshotMountPath,
+						}}),
+						SecurityContext: withContainerSecurityContext(v),
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *ReconcileVault) setUpgradeStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.UpgradeStatus) error {
+	old := v.Status.Upgrade
+	if old != nil && old.Phase == status.Phase {
+		status.LastTransitionTime = old.LastTransitionTime
+	} else {
+		status.LastTransitionTime = metav1.Now()
+	}
+
+	if reflect.DeepEqual(status, old) {
+		return nil
+	}
+
+	v.Status.Upgrade = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Upgrade = status
+	})
+}
@@ -0,0 +1,206 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:  3,
+			Image: "hashicorp/vault:1.20.0",
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+			UpgradeStrategy: &vaultv1alpha1.UpgradeStrategy{
+				Snapshot: &vaultv1alpha1.UpgradeSnapshot{PersistentVolumeClaimName: "vault-snapshots"},
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "vault", Image: "hashicorp/vault:1.19.0"}},
+				},
+			},
+		},
+	}
+
+	reconciler, c := newTestReconciler(v, statefulSet)
+
+	// The image change starts the upgrade, no Pod is updated until the snapshot is saved
+	require.NoError(t, reconciler.detectUpgrade(ctx, v))
+	require.True(t, isUpgrading(v))
+	assert.Equal(t, vaultv1alpha1.UpgradeSnapshotting, v.Status.Upgrade.Phase)
+	assert.Equal(t, "hashicorp/vault:1.19.0", v.Status.Upgrade.FromImage)
+	assert.Equal(t, "hashicorp/vault:1.20.0", v.Status.Upgrade.ToImage)
+
+	// The snapshot is saved by a Job with the old image
+	requeue, err := reconciler.reconcileUpgrade(ctx, v)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+	require.NotEmpty(t, v.Status.Upgrade.SnapshotJob)
+
+	job := &batchv1.Job{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: v.Status.Upgrade.SnapshotJob}, job))
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "hashicorp/vault:1.19.0", container.Image)
+	assert.Equal(t, []string{"vault", "operator", "raft", "snapshot", "save"}, container.Command[:5])
+	assert.Equal(t, "vault-unseal-keys", container.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "vault-root", container.Env[0].ValueFrom.SecretKeyRef.Key)
+
+	// The snapshot Pod must not be selected by the Vault Service
+	selector := labels.SelectorFromSet(v.LabelsForVault())
+	assert.False(t, selector.Matches(labels.Set(job.Labels)))
+	assert.False(t, selector.Matches(labels.Set(job.Spec.Template.Labels)))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, c.Status().Update(ctx, job))
+
+	// The rollout waits until the StatefulSet has picked up the OnDelete strategy
+	_, err = reconciler.reconcileUpgrade(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, vaultv1alpha1.UpgradeRollingOut, v.Status.Upgrade.Phase)
+	assert.Empty(t, v.Status.Upgrade.Pod)
+
+	// The upgrade is kept as long as the image doesn't change
+	require.NoError(t, reconciler.detectUpgrade(ctx, v))
+	assert.Equal(t, vaultv1alpha1.UpgradeRollingOut, v.Status.Upgrade.Phase)
+}
+
+func TestUpgradeOrder(t *testing.T) {
+	ctx := context.Background()
+
+	// vault-1 is the active node, until it steps down for vault-0
+	leader := "vault-1"
+	stepDowns := 0
+	fakeVaults := map[string]*httptest.Server{}
+	for _, podName := range []string{"vault-0", "vault-1", "vault-2"} {
+		podName := podName
+		fakeVaults[podName] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/v1/sys/health":
+				_, _ = fmt.Fprintf(w, `{"initialized": true, "sealed": false, "standby": %t}`, podName != leader)
+			case "/v1/sys/step-down":
+				stepDowns++
+				leader = "vault-0"
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer fakeVaults[podName].Close()
+	}
+
+	podAddress := vaultPodAddress
+	vaultPodAddress = func(_ *vaultv1alpha1.Vault, podName string) string { return fakeVaults[podName].URL }
+	defer func() { vaultPodAddress = podAddress }()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:  3,
+			Image: "hashicorp/vault:1.20.0",
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`),
+			},
+			UpgradeStrategy: &vaultv1alpha1.UpgradeStrategy{},
+		},
+		Status: vaultv1alpha1.VaultStatus{
+			Upgrade: &vaultv1alpha1.UpgradeStatus{
+				Phase:     vaultv1alpha1.UpgradeRollingOut,
+				FromImage: "hashicorp/vault:1.19.0",
+				ToImage:   "hashicorp/vault:1.20.0",
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default", Generation: 2},
+		Spec: appsv1.StatefulSetSpec{
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: "new"},
+	}
+	pod := func(name, revision string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{appsv1.StatefulSetRevisionLabel: revision},
+		}}
+	}
+	unsealKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data:       map[string][]byte{"vault-root": []byte("root")},
+	}
+
+	reconciler, c := newTestReconciler(v, statefulSet, pod("vault-0", "old"), pod("vault-1", "old"), pod("vault-2", "old"), unsealKeys)
+
+	// step updates the next Pod, and recreates it with the update revision like the StatefulSet controller
+	step := func() string {
+		requeue, err := reconciler.reconcileUpgrade(ctx, v)
+		require.NoError(t, err)
+		require.True(t, requeue)
+
+		podName := v.Status.Upgrade.Pod
+		err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: podName}, &corev1.Pod{})
+		if apierrors.IsNotFound(err) {
+			require.NoError(t, c.Create(ctx, pod(podName, "new")))
+		}
+		return podName
+	}
+
+	// The standbys go first from the highest ordinal down
+	assert.Equal(t, "vault-2", step())
+	assert.Equal(t, "vault-0", step())
+	assert.Equal(t, 0, stepDowns)
+
+	// The active node steps down before it is updated, and goes last
+	requeue, err := reconciler.reconcileUpgrade(ctx, v)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+	assert.Equal(t, 1, stepDowns)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-1"}, &corev1.Pod{}))
+
+	assert.Equal(t, "vault-1", step())
+
+	requeue, err = reconciler.reconcileUpgrade(ctx, v)
+	require.NoError(t, err)
+	assert.False(t, requeue)
+	assert.Equal(t, vaultv1alpha1.UpgradeCompleted, v.Status.Upgrade.Phase)
+	assert.Equal(t, 1, stepDowns)
+}
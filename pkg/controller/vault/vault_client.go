@@ -0,0 +1,104 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/bank-vaults/vault-sdk/vault"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tlsSecretName returns the name of the Secret holding the TLS certificates of the Vault
+func tlsSecretName(v *vaultv1alpha1.Vault) string {
+	if v.Spec.ExistingTLSSecretName != "" {
+		return v.Spec.ExistingTLSSecretName
+	}
+	return v.Name + "-tls"
+}
+
+// vaultPodAddress returns the address of the given Vault Pod, tests point it to a fake Vault
+var vaultPodAddress = func(v *vaultv1alpha1.Vault, podName string) string {
+	return fmt.Sprintf("%s://%s.%s:8200", strings.ToLower(string(getVaultURIScheme(v))), podName, v.Namespace)
+}
+
+// newInsecureVaultClientForPod returns a Vault API client which talks to the given Vault Pod directly,
+// without verifying its certificate. It may only be used for the unauthenticated health and seal status endpoints.
+func newInsecureVaultClientForPod(v *vaultv1alpha1.Vault, podName string) (*api.Client, error) {
+	vaultClient, err := vault.NewInsecureRawClient()
+	if err != nil {
+		return nil, err
+	}
+
+	err = vaultClient.SetAddress(vaultPodAddress(v, podName))
+	if err != nil {
+		return nil, err
+	}
+
+	return vaultClient, nil
+}
+
+// NewVaultClientForPod returns a Vault API client which talks to the given Vault Pod directly, and verifies its
+// certificate with the CA in the TLS Secret of the Vault. Unseal keys and tokens are only sent through this client.
+// The certificate has to be valid for the DNS name of the Pod (<pod>.<namespace>), like for the raft retry_join.
+func NewVaultClientForPod(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault, podName string) (*api.Client, error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, config.Error
+	}
+	config.Address = vaultPodAddress(v, podName)
+
+	if !v.Spec.IsTLSDisabled() {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: tlsSecretName(v)}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get the TLS secret of vault %s/%s: %v", v.Namespace, v.Name, err)
+		}
+
+		caCert := secret.Data["ca.crt"]
+		if len(caCert) == 0 {
+			return nil, fmt.Errorf("the TLS secret of vault %s/%s has no ca.crt", v.Namespace, v.Name)
+		}
+
+		err := config.ConfigureTLS(&api.TLSConfig{
+			CACertBytes: caCert,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return api.NewClient(config)
+}
+
+// ActiveVaultClient returns a verified client for the active Vault Pod, or nil if there is none
+func ActiveVaultClient(ctx context.Context, c client.Client, v *vaultv1alpha1.Vault) (*api.Client, error) {
+	for i := 0; i < int(v.Spec.Size); i++ {
+		vaultClient, err := NewVaultClientForPod(ctx, c, v, fmt.Sprintf("%s-%d", v.Name, i))
+		if err != nil {
+			return nil, err
+		}
+
+		health, err := vaultClient.Sys().Health()
+		if err == nil && health.Initialized && !health.Sealed && !health.Standby {
+			return vaultClient, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	bvtls "github.com/bank-vaults/vault-sdk/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewVaultClientForPod(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultSpec{Size: 1},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, vaultv1alpha1.AddToScheme(scheme))
+
+	// Keys and tokens are never sent without the CA
+	_, err := NewVaultClientForPod(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), v, "vault-0")
+	assert.Error(t, err)
+
+	secret := &corev1.Secret{}
+	_, err = populateTLSSecret(v, &corev1.Service{}, nil, secret)
+	require.NoError(t, err)
+	secret.Data = map[string][]byte{}
+	for key, value := range secret.StringData {
+		secret.Data[key] = []byte(value)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	vaultClient, err := NewVaultClientForPod(ctx, c, v, "vault-0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://vault-0.default:8200", vaultClient.Address())
+
+	// The certificate is verified for the DNS name of the Pod, which the generated certificate holds even for a single Pod
+	tlsConfig := vaultClient.CloneConfig().HttpClient.Transport.(*http.Transport).TLSClientConfig
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Empty(t, tlsConfig.ServerName)
+
+	certificate, err := bvtls.PEMToCertificate(secret.Data["server.crt"])
+	require.NoError(t, err)
+	assert.NoError(t, certificate.VerifyHostname("vault-0.default"))
+
+	// Without TLS there is no CA to verify against
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"listener": {"tcp": {"tls_disable": true}}}`)}
+	vaultClient, err = NewVaultClientForPod(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), v, "vault-0")
+	require.NoError(t, err)
+	assert.Equal(t, "http://vault-0.default:8200", vaultClient.Address())
+}
@@ -17,6 +17,7 @@ package vault
 import (
 	"context"
 	"crypto/hmac"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
@@ -25,14 +26,15 @@ import (
 	"net/http"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
 	bvtls "github.com/bank-vaults/vault-sdk/tls"
-	"github.com/bank-vaults/vault-sdk/vault"
 	"github.com/cisco-open/k8s-objectmatcher/patch"
 	"github.com/hashicorp/vault/api"
 	"github.com/imdario/mergo"
@@ -48,6 +50,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -59,7 +63,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-const defaultConfigFile = "vault-config.yml"
+const (
+	defaultConfigFile = "vault-config.yml"
+
+	// zoneAnnotation holds the zone of the Node a zone aware Vault Pod is scheduled to
+	zoneAnnotation = "vault.banzaicloud.io/zone"
+
+	// advertiseAddressAnnotation holds the LoadBalancer address of the per-instance Service of a Vault Pod
+	advertiseAddressAnnotation = "vault.banzaicloud.io/advertise-address"
+)
 
 var (
 	log = logf.Log.WithName("controller_vault")
@@ -88,6 +100,7 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		nonNamespacedClient: nonNamespacedClient,
 		scheme:              mgr.GetScheme(),
 		httpClient:          newHTTPClient(),
+		recorder:            mgr.GetEventRecorderFor("vault-controller"),
 	}, nil
 }
 
@@ -105,6 +118,15 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the VaultFederations, which provide the raft leader of their Vault
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.VaultFederation{},
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, f *vaultv1alpha1.VaultFederation) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: f.Namespace, Name: f.Spec.VaultName}}}
+		})))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -126,6 +148,11 @@ type ReconcileVault struct {
 
 	scheme     *runtime.Scheme
 	httpClient *http.Client
+	recorder   record.EventRecorder
+
+	// escrowKey is the key the unseal shares are submitted with, see escrowSubmissionKey
+	escrowKeyLock sync.Mutex
+	escrowKey     *rsa.PrivateKey
 }
 
 func (r *ReconcileVault) createOrUpdateObject(ctx context.Context, o client.Object) error {
@@ -262,6 +289,37 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 	}
 
+	// A paused Vault is left alone, the status is still reported and refreshed periodically
+	if v.Spec.Paused {
+		if _, _, _, err := r.reportVaultStatus(ctx, request.NamespacedName, v); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// The Vault CR is left as it is, the federation only changes the in-memory Vault the Pods are generated from
+	joinable, err := r.withVaultFederation(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to apply vault federation: %v", err)
+	}
+	if !joinable {
+		reqLogger.Info("waiting for the address of the federation leader")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.reportDeprecatedFields(ctx, v); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to report deprecated fields: %v", err)
+	}
+
+	if err := r.guardVaultVersion(ctx, v); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check vault version: %v", err)
+	}
+
+	if v.Spec.UnsealConfig.OperatorUnseal && !v.Spec.UnsealConfig.IsOperatorUnseal(v) {
+		r.recorder.Event(v, corev1.EventTypeWarning, "OperatorUnsealNotSupported",
+			"operatorUnseal is only supported if the keys are stored in a Kubernetes Secret without hsm and escrow, the unseal sidecar is used instead")
+	}
+
 	// Create the service if it doesn't exist
 	service := serviceForVault(v)
 	// Set Vault instance as the owner and controller
@@ -304,6 +362,21 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
+	// Each Vault instance advertises the address of its own LoadBalancer, so wait for all of them
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		for _, ser := range services {
+			err = r.client.Get(ctx, client.ObjectKeyFromObject(ser), ser)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to get per instance LB service: %v", err)
+			}
+
+			if len(loadBalancerIngressPoints(ser)) == 0 {
+				reqLogger.Info("The per instance LB Service has no Ingress points yet, waiting 5 seconds...", "service", ser.Name)
+				return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+			}
+		}
+	}
+
 	tlsExpiration := time.Time{}
 	if !v.Spec.IsTLSDisabled() {
 		// Check if we have an existing TLS Secret for Vault
@@ -319,7 +392,7 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}, sec)
 		if apierrors.IsNotFound(err) && v.Spec.ExistingTLSSecretName == "" {
 			// If tls secret doesn't exist generate tls
-			tlsExpiration, err = populateTLSSecret(v, service, sec)
+			tlsExpiration, err = populateTLSSecret(v, service, services, sec)
 			if err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to fabricate secret for vault: %v", err)
 			}
@@ -333,7 +406,7 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 			}
 
 			tlsExpiration = certificate.NotAfter
-			tlsHostsChanged := certHostsAndIPsChanged(v, service, certificate)
+			tlsHostsChanged := certHostsAndIPsChanged(v, service, services, certificate)
 
 			// Check if the ca.crt expiration date is closer than the server.crt expiration
 			if caData := sec.Data["ca.crt"]; len(caData) != 0 {
@@ -350,11 +423,11 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 			if time.Until(tlsExpiration) < v.Spec.GetTLSExpiryThreshold() {
 				// Generate new TLS server certificate if expiration date is too close
 				reqLogger.Info("cert expiration date too close", "date", tlsExpiration.UTC().Format(time.RFC3339))
-				tlsExpiration, err = populateTLSSecret(v, service, sec)
+				tlsExpiration, err = populateTLSSecret(v, service, services, sec)
 			} else if tlsHostsChanged {
 				// Generate new TLS server certificate if the TLS hosts have changed
 				reqLogger.Info("TLS server hosts have changed")
-				tlsExpiration, err = populateTLSSecret(v, service, sec)
+				tlsExpiration, err = populateTLSSecret(v, service, services, sec)
 			}
 			if err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to fabricate secret for vault: %v", err)
@@ -443,6 +516,17 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, err
 	}
 
+	// A seal change has to be detected before the Vault Pods can pick up the new config
+	unsupported, err := r.detectSealMigration(ctx, v, rawConfigSum)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to detect seal migration: %v", err)
+	}
+
+	// The Vault Pods keep running with the previous config and seal until the spec is fixed
+	if unsupported {
+		return reconcile.Result{}, nil
+	}
+
 	err = r.createOrUpdateObject(ctx, rawConfigSecret)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to create/update Secret: %v", err)
@@ -452,21 +536,153 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 	restartAnnotations := map[string]string{}
 	restartAnnotations["vault.banzaicloud.io/tls-expiration-date"] = tlsExpiration.UTC().Format(time.RFC3339)
 	restartAnnotations["vault.banzaicloud.io/vault-config"] = rawConfigSum
+
+	// Vault reads the transit token only at startup
+	if v.Spec.UnsealConfig.Transit != nil {
+		accessor, err := r.reconcileTransitUnseal(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile transit unseal: %v", err)
+		}
+		restartAnnotations["vault.banzaicloud.io/transit-unseal-token"] = accessor
+	}
 	statefulSet, err := statefulSetForVault(v, externalSecretsToWatchItems, restartAnnotations, service)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to fabricate StatefulSet: %v", err)
 	}
 
+	staged, err := r.gatePodTemplateChange(ctx, v, statefulSet)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check maintenance windows: %v", err)
+	}
+
+	// Staged changes leave the Pod template as it is, no upgrade or rollout can start
+	if staged {
+		statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition = nil
+		current := &appsv1.StatefulSet{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(statefulSet), current); err == nil {
+			if current.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+				statefulSet.Spec.UpdateStrategy = current.Spec.UpdateStrategy
+			} else if current.Spec.UpdateStrategy.RollingUpdate != nil {
+				statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition = current.Spec.UpdateStrategy.RollingUpdate.Partition
+			}
+		}
+	} else if v.Spec.UpgradeStrategy != nil && !v.Spec.Hibernate {
+		if err := r.detectUpgrade(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to start upgrade: %v", err)
+		}
+		// The Pods are only updated once they are deleted, in the order of the upgrade
+		if isUpgrading(v) {
+			statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+		}
+	}
+
+	// Image upgrades take precedence over config rollouts
+	if v.Spec.RolloutStrategy != nil && !staged && !v.Spec.Hibernate && !isUpgrading(v) {
+		partition, err := r.rolloutPartition(ctx, v, statefulSet, rawConfigSum)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to start rollout: %v", err)
+		}
+		statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+	}
+
+	recreating, err := r.reconcileVolumeExpansion(ctx, v, statefulSet)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to expand volumes: %v", err)
+	}
+	if !recreating {
+		recreating, err = r.reconcileImmutableFields(ctx, v, statefulSet)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to check immutable StatefulSet fields: %v", err)
+		}
+	}
+	if recreating {
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
 	// Set Vault instance as the owner and controller
 	if err := controllerutil.SetControllerReference(v, statefulSet, r.scheme); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	err = r.createOrUpdateObject(ctx, statefulSet)
-	if err != nil {
+	if isImmutableFieldError(err) {
+		if err := r.recreateStatefulSet(ctx, v, statefulSet, "the update has been refused because of an immutable field"); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	} else if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to create/update StatefulSet: %v", err)
 	}
 
+	// A hibernated Vault has no Pods to talk to, only the configurer is scaled down and the status is reported
+	if v.Spec.Hibernate {
+		if len(v.Spec.ExternalConfig.Raw) != 0 {
+			if err := r.deployConfigurer(ctx, v, restartAnnotations); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if _, _, _, err := r.reportVaultStatus(ctx, request.NamespacedName, v); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if v.Spec.RaftRecovery != nil {
+		requeue, err := r.reconcileRaftRecovery(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to recover raft cluster: %v", err)
+		}
+		if requeue {
+			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	orphansPending, err := r.rollOrphanedPods(ctx, v, statefulSet)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to replace orphaned pods: %v", err)
+	}
+
+	// Upgrades and rollouts are not waited for here, the updated Pods may have to be unsealed by the operator below
+	rolloutPending := false
+	if v.Spec.UpgradeStrategy != nil && isUpgrading(v) {
+		rolloutPending, err = r.reconcileUpgrade(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to upgrade vault: %v", err)
+		}
+	}
+	if v.Spec.RolloutStrategy != nil && !isUpgrading(v) && isRollingOut(v) {
+		rolloutPending, err = r.reconcileRollout(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to roll out vault config: %v", err)
+		}
+	}
+
+	if isSealMigrating(v) {
+		requeue, err := r.reconcileSealMigration(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to migrate seal: %v", err)
+		}
+		if requeue {
+			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	zonesPending := false
+	if v.Spec.ZoneAware {
+		zonesPending, err = r.annotatePodZones(ctx, v)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to annotate pods with zones: %v", err)
+		}
+	}
+
+	addressesPending := false
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		addressesPending, err = r.annotatePodAdvertiseAddresses(ctx, v, services)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to annotate pods with advertise addresses: %v", err)
+		}
+	}
+
 	if v.Spec.ServiceMonitorEnabled {
 		// Create the ServiceMonitor if it doesn't exist
 		serviceMonitor := serviceMonitorForVault(v)
@@ -501,78 +717,94 @@ func (r *ReconcileVault) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	}
 
-	// Update the Vault status with the pod names
-	podList := podList()
-	labelSelector := labels.SelectorFromSet(v.LabelsForVault())
-	listOps := &client.ListOptions{
-		LabelSelector: labelSelector,
-		Namespace:     v.Namespace,
+	v, leader, healths, err := r.reportVaultStatus(ctx, request.NamespacedName, v)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
-	err = r.client.List(ctx, podList, listOps)
+	if v == nil {
+		// Request object not found, could have been deleted after reconcile request.
+		// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+		// Return and don't requeue
+		return reconcile.Result{}, nil
+	}
+
+	// The Vault has been fetched again, so the federation has to be applied again for the unseal and join below
+	joinable, err = r.withVaultFederation(ctx, v)
 	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to list pods: %v", err)
+		return reconcile.Result{}, fmt.Errorf("failed to apply vault federation: %v", err)
+	}
+	if !joinable {
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
-	podNames := getPodNames(podList.Items)
 
-	var leader string
-	var statusError string
-	for i := 0; i < int(v.Spec.Size); i++ {
-		tmpClient, err := vault.NewInsecureRawClient()
+	if err := r.recordVaultVersion(ctx, v, leader, healths); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to record vault version: %v", err)
+	}
+
+	unsealPending := false
+	if v.Spec.UnsealConfig.IsOperatorUnseal(v) {
+		unsealPending, err = r.reconcileOperatorUnseal(ctx, v)
 		if err != nil {
-			return reconcile.Result{}, err
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile operator unseal: %v", err)
 		}
+	}
 
-		podName := fmt.Sprintf("%s-%d", v.Name, i)
-		err = tmpClient.SetAddress(fmt.Sprintf("%s://%s.%s:8200", strings.ToLower(string(getVaultURIScheme(v))), podName, v.Namespace))
-		if err != nil {
-			return reconcile.Result{}, err
+	if v.Spec.UnsealConfig.Escrow != nil {
+		if err := r.reconcileUnsealEscrow(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile unseal key escrow: %v", err)
 		}
+	}
 
-		health, err := tmpClient.Sys().Health()
-		if err != nil {
-			statusError = err.Error()
-			break
-		} else if !health.Standby {
-			leader = podName
+	// Rekeying is opt-in, the shares and threshold Vault has been initialized with are unknown otherwise
+	if v.Spec.UnsealConfig.Options.SecretShares != nil || v.Spec.UnsealConfig.Options.SecretThreshold != nil {
+		if err := r.reconcileRekey(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile rekey: %v", err)
 		}
 	}
 
-	// Fetch the Vault instance again to minimize the possibility of updating a stale object
-	// see https://github.com/bank-vaults/vault-operator/issues/364
-	v = &vaultv1alpha1.Vault{}
-	err = r.client.Get(ctx, request.NamespacedName, v)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
+	if v.Spec.EncryptionKeyRotation != nil {
+		if err := r.reconcileEncryptionKeyRotation(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile encryption key rotation: %v", err)
 		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
 	}
 
-	conditionStatus := corev1.ConditionFalse
-	if leader != "" && statusError == "" {
-		conditionStatus = corev1.ConditionTrue
+	if v.Spec.UnsealConfig.RevokesRootToken() {
+		if err := r.reconcileRootTokenRevocation(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile root token revocation: %v", err)
+		}
 	}
 
-	if !reflect.DeepEqual(podNames, v.Status.Nodes) || !reflect.DeepEqual(leader, v.Status.Leader) || len(v.Status.Conditions) == 0 ||
-		v.Status.Conditions[0].Status != conditionStatus || v.Status.Conditions[0].Error != statusError {
-		v.Status.Nodes = podNames
-		v.Status.Leader = leader
-		v.Status.Conditions = []corev1.ComponentCondition{{
-			Type:   corev1.ComponentHealthy,
-			Status: conditionStatus,
-			Error:  statusError,
-		}}
-		log.V(1).Info("Updating vault status", "status", v.Status, "resourceVersion", v.ResourceVersion)
-		err := r.client.Update(ctx, v)
+	// With escrow the sealed Pods wait for the key holders, healing them is pointless
+	var sealedPodsRequeue time.Duration
+	if v.Spec.SealedPodHealing != nil && v.Spec.UnsealConfig.Escrow == nil {
+		sealedPodsRequeue, err = r.reconcileSealedPodHealing(ctx, v, healths)
 		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("failed to update vault status: %v", err)
+			return reconcile.Result{}, fmt.Errorf("failed to heal sealed pods: %v", err)
 		}
 	}
 
+	if len(v.Spec.UnsealConfig.Mirrors) > 0 {
+		if err := r.reconcileUnsealKeyMirrors(ctx, v); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile unseal key mirrors: %v", err)
+		}
+	}
+
+	volumesPending, err := r.reconcileVolumeExpansionProgress(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check volume expansion: %v", err)
+	}
+
+	// Some Vault Pods are still waiting for their zone or advertise address, to be unsealed by the operator, to be updated
+	// or replaced, or their volumes to be expanded
+	if zonesPending || addressesPending || unsealPending || rolloutPending || volumesPending || orphansPending {
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// Some Vault Pods are sealed, and have to be checked again
+	if sealedPodsRequeue > 0 {
+		return reconcile.Result{RequeueAfter: sealedPodsRequeue}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -771,7 +1003,7 @@ func perInstanceServicesForVault(v *vaultv1alpha1.Vault) []*corev1.Service {
 				Labels:      withVaultLabels(v, ls),
 			},
 			Spec: corev1.ServiceSpec{
-				Type:                     corev1.ServiceTypeClusterIP,
+				Type:                     perInstanceServiceType(v),
 				Selector:                 ls,
 				Ports:                    servicePorts,
 				PublishNotReadyAddresses: true,
@@ -823,6 +1055,17 @@ func ingressForVault(v *vaultv1alpha1.Vault) *netv1.Ingress {
 	return nil
 }
 
+// perInstanceServiceType returns the type of the per-instance Services, NodePort is refused by the StatefulSet
+// validation, since the Vault instances can't advertise the addresses of their Nodes
+func perInstanceServiceType(v *vaultv1alpha1.Vault) corev1.ServiceType {
+	switch v.Spec.PerInstanceServiceType {
+	case string(corev1.ServiceTypeLoadBalancer):
+		return corev1.ServiceTypeLoadBalancer
+	default:
+		return corev1.ServiceTypeClusterIP
+	}
+}
+
 func serviceType(v *vaultv1alpha1.Vault) corev1.ServiceType {
 	switch v.Spec.ServiceType {
 	case string(corev1.ServiceTypeClusterIP):
@@ -932,7 +1175,7 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 					ContainerPort: 9091,
 					Protocol:      "TCP",
 				}},
-				Env:          withNamespaceEnv(v, withCommonEnv(v, withTLSEnv(v, false, withCredentialsEnv(v, []corev1.EnvVar{})))),
+				Env:          withUnsealEnv(v, withNamespaceEnv(v, withCommonEnv(v, withTLSEnv(v, false, withCredentialsEnv(v, []corev1.EnvVar{}))))),
 				VolumeMounts: withHSMVolumeMount(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, volumeMounts))),
 				WorkingDir:   "/config",
 				Resources:    getBankVaultsResource(v),
@@ -974,6 +1217,11 @@ func deploymentForConfigurer(v *vaultv1alpha1.Vault, configmaps corev1.ConfigMap
 			},
 		},
 	}
+
+	if v.Spec.Hibernate {
+		dep.Spec.Replicas = ptr.To(int32(0))
+	}
+
 	return dep, nil
 }
 
@@ -1008,7 +1256,7 @@ func hostsForService(svc, namespace string) []string {
 	}
 }
 
-func hostsAndIPsForVault(v *vaultv1alpha1.Vault, service *corev1.Service) []string {
+func hostsAndIPsForVault(v *vaultv1alpha1.Vault, service *corev1.Service, instanceServices []*corev1.Service) []string {
 	hostsAndIPs := []string{"127.0.0.1"}
 
 	hostsAndIPs = append(hostsAndIPs, hostsForService(v.Name, v.Namespace)...)
@@ -1021,11 +1269,14 @@ func hostsAndIPsForVault(v *vaultv1alpha1.Vault, service *corev1.Service) []stri
 		}
 	}
 
-	if v.Spec.Size > 1 {
-		for i := 0; i < int(v.Spec.Size); i++ {
-			hostsAndIPs = append(hostsAndIPs,
-				hostsForService(perInstanceVaultServiceName(v.Name, i), v.Namespace)...)
-		}
+	// The operator talks to each Pod by its own name, even if there is only one
+	for i := 0; i < int(v.Spec.Size); i++ {
+		hostsAndIPs = append(hostsAndIPs,
+			hostsForService(perInstanceVaultServiceName(v.Name, i), v.Namespace)...)
+	}
+
+	for _, instanceService := range instanceServices {
+		hostsAndIPs = append(hostsAndIPs, loadBalancerIngressPoints(instanceService)...)
 	}
 
 	return hostsAndIPs
@@ -1053,8 +1304,8 @@ func loadBalancerIngressPoints(service *corev1.Service) []string {
 }
 
 // populateTLSSecret will populate a secret containing a TLS chain
-func populateTLSSecret(v *vaultv1alpha1.Vault, service *corev1.Service, secret *corev1.Secret) (time.Time, error) {
-	hostsAndIPs := hostsAndIPsForVault(v, service)
+func populateTLSSecret(v *vaultv1alpha1.Vault, service *corev1.Service, instanceServices []*corev1.Service, secret *corev1.Secret) (time.Time, error) {
+	hostsAndIPs := hostsAndIPsForVault(v, service, instanceServices)
 
 	certMgr, err := bvtls.NewCertificateManager(strings.Join(hostsAndIPs, ","), "8760h")
 	if err != nil {
@@ -1121,6 +1372,14 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 	if replicas > 1 && !v.Spec.HasHAStorage() {
 		return nil, fmt.Errorf("more than 1 replicas are not supported without HA storage backend")
 	}
+	if v.Spec.PerInstanceServiceType == string(corev1.ServiceTypeNodePort) {
+		return nil, fmt.Errorf("NodePort per-instance Services are not supported, use LoadBalancer or ClusterIP")
+	}
+
+	// The PersistentVolumeClaims of the scaled down Pods are kept by the StatefulSet
+	if v.Spec.Hibernate {
+		replicas = 0
+	}
 
 	configSizeLimit := resource.MustParse("1Mi")
 
@@ -1144,7 +1403,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		},
 	}))
 
-	volumes = withHSMVolume(v, withStatsdVolume(v, withAuditLogVolume(v, volumes)))
+	volumes = withStepDownVolume(v, withTransitUnsealVolume(v, withRaftRecoveryVolume(v, withAdvertiseAddressVolume(v, withZoneVolume(v, withHSMVolume(v, withStatsdVolume(v, withAuditLogVolume(v, volumes))))))))
 
 	volumeMounts := withTLSVolumeMount(v, withCredentialsVolumeMount(v, []corev1.VolumeMount{
 		{
@@ -1153,25 +1412,16 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		},
 	}))
 
-	volumeMounts = withAuditLogVolumeMount(v, volumeMounts)
+	volumeMounts = withStepDownVolumeMount(v, withTransitUnsealVolumeMount(v, withAuditLogVolumeMount(v, volumeMounts)))
 
 	unsealCommand := []string{"bank-vaults", "unseal", "--init"}
 
-	if v.Spec.IsAutoUnseal() {
+	if isAutoUnsealMode(v) {
 		unsealCommand = append(unsealCommand, "--auto")
 	}
 
 	if v.Spec.IsRaftStorage() {
-		raftLeaderAddress := v.Name
-		raftApiScheme := v.Spec.GetAPIScheme()
-		if v.Spec.IsRaftBootstrapFollower() {
-			raftLeaderAddress = v.Spec.RaftLeaderAddress
-			if v.Spec.RaftLeaderApiSchemeOverride != "" {
-				raftApiScheme = v.Spec.RaftLeaderApiSchemeOverride
-			}
-		}
-
-		unsealCommand = append(unsealCommand, "--raft", "--raft-leader-address", raftApiScheme+"://"+raftLeaderAddress+":8200")
+		unsealCommand = append(unsealCommand, "--raft", "--raft-leader-address", raftLeaderAPIAddr(v))
 
 		if v.Spec.IsRaftBootstrapFollower() {
 			unsealCommand = append(unsealCommand, "--raft-secondary")
@@ -1182,14 +1432,14 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 
 	_, containerPorts := getServicePorts(v)
 
-	containers := withVeleroContainer(v, withStatsDContainer(v, withAuditLogContainer(v, []corev1.Container{
+	containers := withVeleroContainer(v, withStatsDContainer(v, withAuditLogContainer(v, withoutUnsealSidecar(v, []corev1.Container{
 		{
 			Image:           v.Spec.GetVaultImage(),
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			Name:            "vault",
 			Args:            []string{"server"},
 			Ports:           containerPorts,
-			Env: withClusterAddr(v, service, withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
+			Env: withTransitUnsealEnv(v, withClusterAddr(v, service, withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
 				{
 					Name: "VAULT_K8S_POD_NAME",
 					ValueFrom: &corev1.EnvVarSource{
@@ -1198,7 +1448,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 						},
 					},
 				},
-			}))),
+			})))),
 			SecurityContext: withContainerSecurityContext(v),
 			// This probe allows Vault extra time to be responsive in a HTTPS manner during startup
 			// See: https://www.vaultproject.io/api/system/init.html
@@ -1237,6 +1487,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 				PeriodSeconds:    5,
 				FailureThreshold: 2,
 			},
+			Lifecycle:    withStepDownLifecycle(v),
 			VolumeMounts: withVaultVolumeMounts(v, volumeMounts),
 			Resources:    getVaultResource(v),
 		},
@@ -1246,7 +1497,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			Name:            "bank-vaults",
 			Command:         unsealCommand,
 			Args:            v.Spec.UnsealConfig.ToArgs(v),
-			Env: withSidecarEnv(v, withTLSEnv(v, true, withCredentialsEnv(v, withCommonEnv(v, []corev1.EnvVar{
+			Env: withUnsealEnv(v, withSidecarEnv(v, withTLSEnv(v, true, withCredentialsEnv(v, withCommonEnv(v, []corev1.EnvVar{
 				{
 					Name: "POD_NAME",
 					ValueFrom: &corev1.EnvVarSource{
@@ -1255,7 +1506,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 						},
 					},
 				},
-			})))),
+			}))))),
 			Ports: []corev1.ContainerPort{{
 				Name:          "metrics",
 				ContainerPort: 9091,
@@ -1264,7 +1515,7 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 			VolumeMounts: withHSMVolumeMount(v, withBanksVaultsVolumeMounts(v, withTLSVolumeMount(v, withCredentialsVolumeMount(v, []corev1.VolumeMount{})))),
 			Resources:    getBankVaultsResource(v),
 		},
-	})))
+	}))))
 
 	if v.Spec.UnsealConfig.HSMDaemonNeeded() {
 		containers = append(containers, corev1.Container{
@@ -1296,13 +1547,13 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 		ServiceAccountName:           v.Spec.GetServiceAccount(),
 		AutomountServiceAccountToken: ptr.To(true),
 
-		InitContainers: withVaultInitContainers(v, []corev1.Container{
-			{
+		InitContainers: withVaultInitContainers(v, withRaftRecoveryInitContainer(v, append(withPodAnnotationsInitContainer(v, nil),
+			corev1.Container{
 				Image:           v.Spec.GetBankVaultsImage(),
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				Name:            "config-templating",
 				Command:         []string{"template", "-template", fmt.Sprintf("/tmp/vault-config.json:%s/vault.json", v.Spec.GetConfigPath())},
-				Env: withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
+				Env: withPodAnnotationsEnv(v, withTransitUnsealTemplateEnv(v, withCredentialsEnv(v, withVaultEnv(v, []corev1.EnvVar{
 					{
 						Name: "POD_NAME",
 						ValueFrom: &corev1.EnvVarSource{
@@ -1311,20 +1562,22 @@ func statefulSetForVault(v *vaultv1alpha1.Vault, externalSecretsToWatchItems []c
 							},
 						},
 					},
-				})),
+				})))),
 				VolumeMounts: withVaultVolumeMounts(v, append(volumeMounts, corev1.VolumeMount{
 					Name:      "vault-raw-config",
 					MountPath: "/tmp",
 				})),
 				Resources: getVaultResource(v),
 			},
-		}),
+		))),
 
-		Containers:      containers,
-		Volumes:         withVaultVolumes(v, volumes),
-		SecurityContext: withPodSecurityContext(v),
-		NodeSelector:    v.Spec.NodeSelector,
-		Tolerations:     v.Spec.Tolerations,
+		Containers:                    containers,
+		Volumes:                       withVaultVolumes(v, volumes),
+		SecurityContext:               withPodSecurityContext(v),
+		NodeSelector:                  v.Spec.NodeSelector,
+		Tolerations:                   v.Spec.Tolerations,
+		TopologySpreadConstraints:     getTopologySpreadConstraints(v),
+		TerminationGracePeriodSeconds: getTerminationGracePeriodSeconds(v),
 	}
 
 	// merge provided VaultPodSpec into the PodSpec defined above
@@ -1606,10 +1859,9 @@ func withClusterAddr(v *vaultv1alpha1.Vault, service *corev1.Service, envs []cor
 	}
 
 	// Only applies to multi-cluster setups:
-	// This currently allows only one instance per cluster,
-	// since the cluster_addr is bound to the LB address.
-	// Possible workaround: 1 LB/Vault member instance.
-	if value != "" && v.Spec.RaftLeaderAddress != "" {
+	// This allows only one instance per cluster, since the cluster_addr is bound to the LB address,
+	// with per-instance LoadBalancers each instance advertises its own LB address through the config instead.
+	if value != "" && v.Spec.RaftLeaderAddress != "" && !v.Spec.IsPerInstanceLoadBalancer() {
 		envs = append(envs, corev1.EnvVar{
 			Name:  "VAULT_CLUSTER_ADDR",
 			Value: "https://" + value + ":8201",
@@ -1668,6 +1920,127 @@ func withStatsdVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.
 	return volumes
 }
 
+// The zone of a Vault Pod's Node is not available through the downward API, so the operator copies it
+// into a Pod annotation, which is exposed to the config templating init container as a file.
+func withZoneVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if v.Spec.ZoneAware {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-zone",
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{
+						{
+							Path: "zone",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: fmt.Sprintf("metadata.annotations['%s']", zoneAnnotation),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func withZoneVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if v.Spec.ZoneAware {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-zone",
+			MountPath: "/vault/zone",
+		})
+	}
+	return volumeMounts
+}
+
+// withAdvertiseAddressVolume exposes the LoadBalancer address of the per-instance Service,
+// which is copied into a Pod annotation by the operator, to the config templating init container as a file.
+func withAdvertiseAddressVolume(v *vaultv1alpha1.Vault, volumes []corev1.Volume) []corev1.Volume {
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		volumes = append(volumes, corev1.Volume{
+			Name: "vault-advertise-address",
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{
+						{
+							Path: "address",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: fmt.Sprintf("metadata.annotations['%s']", advertiseAddressAnnotation),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func withAdvertiseAddressVolumeMount(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "vault-advertise-address",
+			MountPath: "/vault/advertise-address",
+		})
+	}
+	return volumeMounts
+}
+
+// withPodAnnotationsInitContainer waits until the operator annotates the Pod with its zone and advertise address,
+// the config templating init container reads them afterwards. It runs the Vault image, which comes with a shell.
+func withPodAnnotationsInitContainer(v *vaultv1alpha1.Vault, containers []corev1.Container) []corev1.Container {
+	var script string
+
+	if v.Spec.ZoneAware {
+		script += "until [ -s /vault/zone/zone ]; do echo waiting for the zone of the node; sleep 2; done; "
+	}
+
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		script += "until [ -s /vault/advertise-address/address ]; do echo waiting for the advertise address; sleep 2; done; "
+	}
+
+	if script == "" {
+		return containers
+	}
+
+	return append(containers, corev1.Container{
+		Image:           v.Spec.GetVaultImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            "pod-annotations",
+		Command:         []string{"/bin/sh", "-c", script},
+		VolumeMounts:    withAdvertiseAddressVolumeMount(v, withZoneVolumeMount(v, nil)),
+		Resources:       getVaultResource(v),
+	})
+}
+
+// withPodAnnotationsEnv exposes the zone and advertise address annotations of the Pod to the config templating
+// init container, they are set by the time it starts
+func withPodAnnotationsEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	if v.Spec.ZoneAware {
+		envs = append(envs, corev1.EnvVar{
+			Name: "VAULT_REDUNDANCY_ZONE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", zoneAnnotation),
+				},
+			},
+		})
+	}
+
+	if v.Spec.IsPerInstanceLoadBalancer() {
+		envs = append(envs, corev1.EnvVar{
+			Name: "VAULT_ADVERTISE_ADDRESS",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", advertiseAddressAnnotation),
+				},
+			},
+		})
+	}
+
+	return envs
+}
+
 func withVaultInitContainers(v *vaultv1alpha1.Vault, containers []corev1.Container) []corev1.Container {
 	return append(containers, v.Spec.VaultInitContainers...)
 }
@@ -1830,6 +2203,33 @@ func getPodAntiAffinity(v *vaultv1alpha1.Vault) *corev1.PodAntiAffinity {
 	}
 }
 
+// getTopologySpreadConstraints spreads the Vault Pods evenly across zones, and on a best-effort basis across Nodes
+func getTopologySpreadConstraints(v *vaultv1alpha1.Vault) []corev1.TopologySpreadConstraint {
+	if !v.Spec.ZoneAware {
+		return nil
+	}
+
+	ls := v.LabelsForVault()
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: ls,
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelHostname,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: ls,
+			},
+		},
+	}
+}
+
 func getNodeAffinity(v *vaultv1alpha1.Vault) *corev1.NodeAffinity {
 	if v.Spec.NodeAffinity.Size() == 0 {
 		return nil
@@ -1844,6 +2244,20 @@ func getVaultURIScheme(v *vaultv1alpha1.Vault) corev1.URIScheme {
 	return corev1.URISchemeHTTPS
 }
 
+// raftLeaderAPIAddr returns the API address of the raft leader the Vault Pods join
+func raftLeaderAPIAddr(v *vaultv1alpha1.Vault) string {
+	raftLeaderAddress := v.Name
+	raftApiScheme := v.Spec.GetAPIScheme()
+	if v.Spec.IsRaftBootstrapFollower() {
+		raftLeaderAddress = v.Spec.RaftLeaderAddress
+		if v.Spec.RaftLeaderApiSchemeOverride != "" {
+			raftApiScheme = v.Spec.RaftLeaderApiSchemeOverride
+		}
+	}
+
+	return raftApiScheme + "://" + raftLeaderAddress + ":8200"
+}
+
 func withBanksVaultsVolumeMounts(v *vaultv1alpha1.Vault, volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
 	index := map[string]corev1.VolumeMount{}
 	for _, v := range append(volumeMounts, v.Spec.BankVaultsVolumeMounts...) {
@@ -1928,6 +2342,11 @@ func withCommonEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar
 	return append(envs, v.Spec.EnvsConfig...)
 }
 
+// withUnsealEnv passes the sensitive unseal parameters selected from Secrets to Bank-Vaults
+func withUnsealEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
+	return append(envs, v.Spec.UnsealConfig.ToEnv()...)
+}
+
 func withSidecarEnv(v *vaultv1alpha1.Vault, envs []corev1.EnvVar) []corev1.EnvVar {
 	return append(envs, v.Spec.SidecarEnvsConfig...)
 }
@@ -2164,9 +2583,9 @@ func (r *ReconcileVault) distributeCACertificate(ctx context.Context, v *vaultv1
 	return nil
 }
 
-func certHostsAndIPsChanged(v *vaultv1alpha1.Vault, service *corev1.Service, cert *x509.Certificate) bool {
+func certHostsAndIPsChanged(v *vaultv1alpha1.Vault, service *corev1.Service, instanceServices []*corev1.Service, cert *x509.Certificate) bool {
 	// TODO very weak check for now
-	return len(cert.DNSNames)+len(cert.IPAddresses) != len(hostsAndIPsForVault(v, service))
+	return len(cert.DNSNames)+len(cert.IPAddresses) != len(hostsAndIPsForVault(v, service, instanceServices))
 }
 
 func (r *ReconcileVault) deployConfigurer(ctx context.Context, v *vaultv1alpha1.Vault, tlsAnnotations map[string]string) error {
@@ -2239,6 +2658,115 @@ func (r *ReconcileVault) deployConfigurer(ctx context.Context, v *vaultv1alpha1.
 	return nil
 }
 
+// reportVaultStatus updates the Vault status with the Pod names and the health of the Vault Pods.
+// It returns the Vault fetched again, or nil if it has been deleted, the active Pod, and the healths of the Pods.
+func (r *ReconcileVault) reportVaultStatus(ctx context.Context, key client.ObjectKey, v *vaultv1alpha1.Vault) (*vaultv1alpha1.Vault, string, map[string]*api.HealthResponse, error) {
+	podList := podList()
+	labelSelector := labels.SelectorFromSet(v.LabelsForVault())
+	listOps := &client.ListOptions{
+		LabelSelector: labelSelector,
+		Namespace:     v.Namespace,
+	}
+	err := r.client.List(ctx, podList, listOps)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	podNames := getPodNames(podList.Items)
+
+	// A hibernated Vault has no Pods to check
+	size := int(v.Spec.Size)
+	if v.Spec.Hibernate {
+		size = 0
+	}
+
+	var leader string
+	var statusError string
+	healths := map[string]*api.HealthResponse{}
+	for i := 0; i < size; i++ {
+		podName := fmt.Sprintf("%s-%d", v.Name, i)
+		tmpClient, err := newInsecureVaultClientForPod(v, podName)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		health, err := tmpClient.Sys().Health()
+		if err != nil {
+			statusError = err.Error()
+			break
+		} else if !health.Standby {
+			leader = podName
+		}
+		healths[podName] = health
+	}
+
+	// Fetch the Vault instance again to minimize the possibility of updating a stale object
+	// see https://github.com/bank-vaults/vault-operator/issues/364
+	v = &vaultv1alpha1.Vault{}
+	err = r.client.Get(ctx, key, v)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", nil, nil
+		}
+		// Error reading the object - requeue the request.
+		return nil, "", nil, err
+	}
+
+	conditionStatus := corev1.ConditionFalse
+	if leader != "" && statusError == "" {
+		conditionStatus = corev1.ConditionTrue
+	}
+
+	var message string
+	switch {
+	case v.Spec.Paused:
+		message = "reconciliation is paused"
+	case v.Spec.Hibernate:
+		message = "vault is hibernated"
+	}
+
+	if !reflect.DeepEqual(podNames, v.Status.Nodes) || !reflect.DeepEqual(leader, v.Status.Leader) || len(v.Status.Conditions) == 0 ||
+		v.Status.Conditions[0].Status != conditionStatus || v.Status.Conditions[0].Error != statusError ||
+		v.Status.Conditions[0].Message != message {
+		v.Status.Nodes = podNames
+		v.Status.Leader = leader
+		v.Status.Conditions = []corev1.ComponentCondition{{
+			Type:    corev1.ComponentHealthy,
+			Status:  conditionStatus,
+			Message: message,
+			Error:   statusError,
+		}}
+		log.V(1).Info("Updating vault status", "status", v.Status, "resourceVersion", v.ResourceVersion)
+		err := r.client.Update(ctx, v)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to update vault status: %v", err)
+		}
+	}
+
+	return v, leader, healths, nil
+}
+
+// reportDeprecatedFields records the deprecated fields in use in the status, and emits a Warning Event
+// only for the ones which weren't in use before, instead of on every reconcile
+func (r *ReconcileVault) reportDeprecatedFields(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	fields := v.Spec.UnsealConfig.DeprecatedFields()
+	if slices.Equal(fields, v.Status.DeprecatedFields) {
+		return nil
+	}
+
+	for _, field := range fields {
+		if !slices.Contains(v.Status.DeprecatedFields, field) {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "DeprecatedField",
+				"%s is deprecated, since it exposes the secret in the Pod spec, use a Secret reference instead", field)
+		}
+	}
+
+	v.Status.DeprecatedFields = fields
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.DeprecatedFields = fields
+	})
+}
+
 // handleStorageConfiguration checks if storage configuration is present in the Vault CRD
 // and updates status with a condition if it's missing
 func (r *ReconcileVault) handleStorageConfiguration(ctx context.Context, v *vaultv1alpha1.Vault) error {
@@ -2252,11 +2780,7 @@ func (r *ReconcileVault) handleStorageConfiguration(ctx context.Context, v *vaul
 		}
 
 		// Update Vault's status with the new condition
-		v.Status = vaultv1alpha1.VaultStatus{
-			Nodes:      v.Status.Nodes,
-			Leader:     v.Status.Leader,
-			Conditions: []corev1.ComponentCondition{condition},
-		}
+		v.Status.Conditions = []corev1.ComponentCondition{condition}
 
 		// Update Kubernetes with the new Vault status
 		err := r.client.Status().Update(ctx, v)
@@ -2269,3 +2793,153 @@ func (r *ReconcileVault) handleStorageConfiguration(ctx context.Context, v *vaul
 
 	return nil
 }
+
+// annotatePodZones copies the zone label of the Node each Vault Pod is scheduled to into a Pod annotation,
+// it returns true if some of the Pods are not scheduled yet
+func (r *ReconcileVault) annotatePodZones(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	pods := podList()
+	listOps := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(v.LabelsForVault()),
+		Namespace:     v.Namespace,
+	}
+	if err := r.client.List(ctx, pods, listOps); err != nil {
+		return false, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	// Pods of the StatefulSet might not be created yet
+	pending := len(pods.Items) < int(v.Spec.Size)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if _, ok := pod.Annotations[zoneAnnotation]; ok {
+			continue
+		}
+
+		if pod.Spec.NodeName == "" {
+			pending = true
+			continue
+		}
+
+		node := &corev1.Node{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+			return false, fmt.Errorf("failed to get node %s: %v", pod.Spec.NodeName, err)
+		}
+
+		// The rest of the reconcile goes on, the Pod waits until the Node is labelled
+		zone := node.Labels[corev1.LabelTopologyZone]
+		if zone == "" {
+			r.recorder.Eventf(v, corev1.EventTypeWarning, "NodeZoneMissing", "node %s of pod %s has no %s label",
+				node.Name, pod.Name, corev1.LabelTopologyZone)
+			pending = true
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[zoneAnnotation] = zone
+
+		if err := r.client.Patch(ctx, pod, patch); err != nil {
+			return false, fmt.Errorf("failed to annotate pod %s: %v", pod.Name, err)
+		}
+
+		log.V(1).Info("annotated pod with zone", "pod", pod.Name, "zone", zone)
+	}
+
+	return pending, nil
+}
+
+// annotatePodAdvertiseAddresses copies the LoadBalancer address of each per-instance Service into the
+// annotation of the Vault Pod behind it, it returns true if some of the Pods are not created yet. The address
+// is rendered into the Vault config when the Pod starts, so a Pod advertising a stale address is replaced,
+// one at a time while the rest of the Vault Pods are ready.
+func (r *ReconcileVault) annotatePodAdvertiseAddresses(ctx context.Context, v *vaultv1alpha1.Vault, instanceServices []*corev1.Service) (bool, error) {
+	pending := false
+	othersReady := true
+	var stale []*corev1.Pod
+	for _, instanceService := range instanceServices {
+		ingressPoints := loadBalancerIngressPoints(instanceService)
+		if len(ingressPoints) == 0 {
+			pending = true
+			othersReady = false
+			continue
+		}
+		address := ingressPoints[len(ingressPoints)-1]
+
+		// The per-instance Services are named after the Pods
+		pod := &corev1.Pod{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: instanceService.Name}, pod)
+		if apierrors.IsNotFound(err) {
+			pending = true
+			othersReady = false
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get pod %s: %v", instanceService.Name, err)
+		}
+
+		current := pod.Annotations[advertiseAddressAnnotation]
+		if current == address {
+			othersReady = othersReady && pod.DeletionTimestamp == nil && podReady(pod)
+			continue
+		}
+
+		if current != "" {
+			stale = append(stale, pod)
+			continue
+		}
+
+		othersReady = false
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[advertiseAddressAnnotation] = address
+
+		if err := r.client.Patch(ctx, pod, patch); err != nil {
+			return false, fmt.Errorf("failed to annotate pod %s: %v", pod.Name, err)
+		}
+
+		log.V(1).Info("annotated pod with advertise address", "pod", pod.Name, "address", address)
+	}
+
+	if len(stale) == 0 {
+		return pending, nil
+	}
+
+	for _, pod := range stale[1:] {
+		othersReady = othersReady && pod.DeletionTimestamp == nil && podReady(pod)
+	}
+
+	// The replacement Pod is annotated with the new address once it is created
+	pod := stale[0]
+	if othersReady && pod.DeletionTimestamp == nil {
+		if _, err := r.stepDownIfActive(ctx, v, pod.Name); err != nil {
+			log.Error(err, "failed to step down the active vault before replacing it", "pod", pod.Name)
+		}
+
+		if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete pod %s: %v", pod.Name, err)
+		}
+
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "AdvertiseAddressChanged",
+			"the LoadBalancer address of pod %s has changed from %s, it is replaced", pod.Name, pod.Annotations[advertiseAddressAnnotation])
+	}
+
+	return true, nil
+}
+
+// updateVault applies the mutation on the latest version of the Vault CR and updates it,
+// this way the defaults filled in by the getters of the reconciled object are not persisted
+func (r *ReconcileVault) updateVault(ctx context.Context, key client.ObjectKey, mutate func(*vaultv1alpha1.Vault)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		v := &vaultv1alpha1.Vault{}
+		if err := r.client.Get(ctx, key, v); err != nil {
+			return err
+		}
+
+		mutate(v)
+
+		return r.client.Update(ctx, v)
+	})
+}
@@ -18,14 +18,21 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var envs = []corev1.EnvVar{
@@ -291,3 +298,268 @@ func TestWithVaultEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestZoneAwareStatefulSet(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault",
+			Namespace: "default",
+		},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:      3,
+			ZoneAware: true,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+		},
+	}
+
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	assert.NoError(t, err)
+
+	podSpec := statefulSet.Spec.Template.Spec
+	assert.Len(t, podSpec.TopologySpreadConstraints, 2)
+	assert.Equal(t, corev1.LabelTopologyZone, podSpec.TopologySpreadConstraints[0].TopologyKey)
+	assert.Equal(t, corev1.DoNotSchedule, podSpec.TopologySpreadConstraints[0].WhenUnsatisfiable)
+
+	// The zone is waited for in the Vault image, the bank-vaults image might have no shell
+	podAnnotations := podSpec.InitContainers[0]
+	assert.Equal(t, "pod-annotations", podAnnotations.Name)
+	assert.Equal(t, v.Spec.GetVaultImage(), podAnnotations.Image)
+	assert.Contains(t, podAnnotations.Command[2], "/vault/zone/zone")
+	assert.Contains(t, podAnnotations.VolumeMounts, corev1.VolumeMount{Name: "vault-zone", MountPath: "/vault/zone"})
+
+	configTemplating := podSpec.InitContainers[1]
+	assert.Equal(t, []string{"template", "-template", "/tmp/vault-config.json:/vault/config/vault.json"}, configTemplating.Command)
+	assert.Contains(t, configTemplating.Env, corev1.EnvVar{
+		Name: "VAULT_REDUNDANCY_ZONE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['vault.banzaicloud.io/zone']"},
+		},
+	})
+}
+
+func TestAnnotatePodZones(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultSpec{Size: 2, ZoneAware: true},
+	}
+	pod := func(name, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: v.LabelsForVault()},
+			Spec:       corev1.PodSpec{NodeName: node},
+		}
+	}
+
+	r, c := newTestReconciler(
+		pod("vault-0", "node-a"),
+		pod("vault-1", "node-b"),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+	)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	// A Node without a zone label leaves its Pod pending, instead of failing the reconcile
+	pending, err := r.annotatePodZones(ctx, v)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	assert.Len(t, recorder.Events, 1)
+
+	annotated := &corev1.Pod{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-0"}, annotated))
+	assert.Equal(t, "zone-a", annotated.Annotations[zoneAnnotation])
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-1"}, annotated))
+	assert.Empty(t, annotated.Annotations[zoneAnnotation])
+}
+
+func TestPerInstanceLoadBalancer(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault",
+			Namespace: "default",
+		},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:                   3,
+			RaftLeaderAddress:      "vault.primary.example.com",
+			PerInstanceServiceType: "LoadBalancer",
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "api_addr": "https://vault.example.com:8200"}`),
+			},
+		},
+	}
+
+	services := perInstanceServicesForVault(v)
+	assert.Len(t, services, 3)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, services[0].Spec.Type)
+
+	// The address of the main LoadBalancer is not advertised by every instance
+	service := &corev1.Service{}
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	assert.Empty(t, withClusterAddr(v, service, nil))
+
+	services[1].Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "vault-1.example.com"}}
+	assert.Contains(t, hostsAndIPsForVault(v, service, services), "vault-1.example.com")
+
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, service)
+	assert.NoError(t, err)
+
+	podAnnotations := statefulSet.Spec.Template.Spec.InitContainers[0]
+	assert.Contains(t, podAnnotations.Command[2], "/vault/advertise-address/address")
+	assert.Contains(t, podAnnotations.VolumeMounts, corev1.VolumeMount{Name: "vault-advertise-address", MountPath: "/vault/advertise-address"})
+
+	configTemplating := statefulSet.Spec.Template.Spec.InitContainers[1]
+	assert.Contains(t, configTemplating.Env, corev1.EnvVar{
+		Name: "VAULT_ADVERTISE_ADDRESS",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['vault.banzaicloud.io/advertise-address']"},
+		},
+	})
+
+	config, err := v.ConfigJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(config), `"cluster_addr":"https://${.Env.VAULT_ADVERTISE_ADDRESS}:8201"`)
+	// The addresses set by the user are kept
+	assert.Contains(t, string(config), `"api_addr":"https://vault.example.com:8200"`)
+
+	// The Nodes' addresses can't be advertised
+	v.Spec.PerInstanceServiceType = "NodePort"
+	assert.Equal(t, corev1.ServiceTypeClusterIP, perInstanceServiceType(v))
+	_, err = statefulSetForVault(v, nil, map[string]string{}, service)
+	assert.Error(t, err)
+}
+
+func TestAnnotatePodAdvertiseAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultSpec{Size: 2, PerInstanceServiceType: "LoadBalancer"},
+	}
+	ready := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	pod := func(name, address string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{advertiseAddressAnnotation: address},
+			},
+			Status: corev1.PodStatus{Conditions: ready},
+		}
+	}
+
+	r, c := newTestReconciler(pod("vault-0", "10.0.0.1"), pod("vault-1", "10.0.0.2"))
+
+	services := perInstanceServicesForVault(v)
+	services[0].Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	services[1].Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.2"}}
+
+	pending, err := r.annotatePodAdvertiseAddresses(ctx, v, services)
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	// The Vault config is rendered at start, so the Pod with a changed address is replaced
+	services[1].Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.3"}}
+	pending, err = r.annotatePodAdvertiseAddresses(ctx, v, services)
+	require.NoError(t, err)
+	assert.True(t, pending)
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-1"}, &corev1.Pod{})
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-0"}, &corev1.Pod{}))
+}
+
+func TestPausedVault(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   1,
+			Paused: true,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+		},
+	}
+
+	r, c := newTestReconciler(v)
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(v)})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	// Nothing is created for a paused Vault
+	services := &corev1.ServiceList{}
+	require.NoError(t, c.List(ctx, services))
+	assert.Empty(t, services.Items)
+	statefulSets := &appsv1.StatefulSetList{}
+	require.NoError(t, c.List(ctx, statefulSets))
+	assert.Empty(t, statefulSets.Items)
+
+	updated := &vaultv1alpha1.Vault{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), updated))
+	require.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, "reconciliation is paused", updated.Status.Conditions[0].Message)
+	assert.Equal(t, corev1.ConditionFalse, updated.Status.Conditions[0].Status)
+}
+
+func TestHibernatedVault(t *testing.T) {
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:      3,
+			Hibernate: true,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`),
+			},
+		},
+	}
+
+	statefulSet, err := statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *statefulSet.Spec.Replicas)
+
+	deployment, err := deploymentForConfigurer(v, corev1.ConfigMapList{}, corev1.SecretList{}, map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	v.Spec.Hibernate = false
+	statefulSet, err = statefulSetForVault(v, nil, map[string]string{}, &corev1.Service{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+}
+
+func TestReportDeprecatedFields(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			UnsealConfig: vaultv1alpha1.UnsealConfig{
+				HSM: &vaultv1alpha1.HSMUnsealConfig{Pin: "banzai"},
+			},
+		},
+	}
+
+	r, c := newTestReconciler(v)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	require.NoError(t, r.reportDeprecatedFields(ctx, v))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	assert.Equal(t, []string{"unsealConfig.hsm.pin"}, v.Status.DeprecatedFields)
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// The same fields are only reported once
+	require.NoError(t, r.reportDeprecatedFields(ctx, v))
+	assert.Empty(t, recorder.Events)
+
+	// The status is cleared once the deprecated fields are gone
+	v.Spec.UnsealConfig.HSM.Pin = ""
+	require.NoError(t, r.reportDeprecatedFields(ctx, v))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), v))
+	assert.Empty(t, v.Status.DeprecatedFields)
+	assert.Empty(t, recorder.Events)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	federationLeaderCAVolumeName = "vault-federation-leader-ca"
+	federationLeaderCAMountPath  = "/vault/raft-tls/"
+)
+
+// federationForVault returns the valid VaultFederation the Vault is a member of, or nil
+func (r *ReconcileVault) federationForVault(ctx context.Context, v *vaultv1alpha1.Vault) (*vaultv1alpha1.VaultFederation, error) {
+	federations := &vaultv1alpha1.VaultFederationList{}
+	if err := r.client.List(ctx, federations, client.InNamespace(v.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list vault federations: %v", err)
+	}
+
+	for i := range federations.Items {
+		f := &federations.Items[i]
+		if f.Spec.VaultName == v.Name && f.Spec.Validate() == nil {
+			return f, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// withVaultFederation applies the raft leader of the VaultFederation of the Vault to the in-memory Vault, the Vault CR
+// itself is never updated with it. It returns false while a follower waits for the address of the bootstrap leader,
+// so its Pods aren't started before they can join, and initialize a raft cluster of their own instead.
+func (r *ReconcileVault) withVaultFederation(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	f, err := r.federationForVault(ctx, v)
+	if err != nil || f == nil {
+		return err == nil, err
+	}
+
+	if f.Spec.IsBootstrapLeader() {
+		v.Spec.RaftLeaderAddress = "self"
+		return true, nil
+	}
+
+	if f.Status.LeaderAddress == "" {
+		return false, nil
+	}
+
+	v.Spec.RaftLeaderAddress = f.Status.LeaderAddress
+
+	if f.Status.LeaderCASecretName != "" {
+		v.Spec.Volumes = append(v.Spec.Volumes, corev1.Volume{
+			Name: federationLeaderCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: f.Status.LeaderCASecretName,
+				},
+			},
+		})
+		v.Spec.BankVaultsVolumeMounts = append(v.Spec.BankVaultsVolumeMounts, corev1.VolumeMount{
+			Name:      federationLeaderCAVolumeName,
+			MountPath: federationLeaderCAMountPath,
+		})
+		v.Spec.SidecarEnvsConfig = append(v.Spec.SidecarEnvsConfig, corev1.EnvVar{
+			Name:  "VAULT_RAFT_CACERT",
+			Value: federationLeaderCAMountPath + "ca.crt",
+		})
+	}
+
+	return true, nil
+}
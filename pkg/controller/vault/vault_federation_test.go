@@ -0,0 +1,164 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWithVaultFederation(t *testing.T) {
+	ctx := context.Background()
+
+	f := &vaultv1alpha1.VaultFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-dc", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultFederationSpec{
+			VaultName:    "vault",
+			LocalCluster: "secondary",
+			Members: []vaultv1alpha1.VaultFederationMember{
+				{Name: "primary", Address: "vault.primary.example.com"},
+				{Name: "secondary"},
+			},
+		},
+	}
+	v := &vaultv1alpha1.Vault{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"}}
+
+	reconciler, c := newTestReconciler(f, v)
+
+	// A follower must not start before it knows the leader, otherwise it bootstraps a raft cluster of its own
+	joinable, err := reconciler.withVaultFederation(ctx, v.DeepCopy())
+	require.NoError(t, err)
+	assert.False(t, joinable)
+
+	f.Status.LeaderAddress = "vault.primary.example.com"
+	f.Status.LeaderCASecretName = "multi-dc-leader-ca"
+	require.NoError(t, c.Update(ctx, f))
+
+	federated := v.DeepCopy()
+	joinable, err = reconciler.withVaultFederation(ctx, federated)
+	require.NoError(t, err)
+	assert.True(t, joinable)
+	assert.Equal(t, "vault.primary.example.com", federated.Spec.RaftLeaderAddress)
+	assert.Equal(t, "https://vault.primary.example.com:8200", raftLeaderAPIAddr(federated))
+	require.Len(t, federated.Spec.Volumes, 1)
+	assert.Equal(t, "multi-dc-leader-ca", federated.Spec.Volumes[0].Secret.SecretName)
+	require.Len(t, federated.Spec.SidecarEnvsConfig, 1)
+	assert.Equal(t, "/vault/raft-tls/ca.crt", federated.Spec.SidecarEnvsConfig[0].Value)
+
+	// The status of the Vault is written without the federation
+	_, _, _, err = reconciler.reportVaultStatus(ctx, client.ObjectKeyFromObject(v), federated)
+	require.NoError(t, err)
+	current := &vaultv1alpha1.Vault{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), current))
+	assert.Empty(t, current.Spec.RaftLeaderAddress)
+	assert.Empty(t, current.Spec.Volumes)
+
+	// The bootstrap leader initializes the raft cluster
+	f.Spec.LocalCluster = "primary"
+	require.NoError(t, c.Update(ctx, f))
+	federated = v.DeepCopy()
+	joinable, err = reconciler.withVaultFederation(ctx, federated)
+	require.NoError(t, err)
+	assert.True(t, joinable)
+	assert.Equal(t, "self", federated.Spec.RaftLeaderAddress)
+}
+
+func TestReconcileFederatedFollower(t *testing.T) {
+	ctx := context.Background()
+
+	var joins []map[string]interface{}
+	initialized := false
+	fakeVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/sys/health":
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = w.Write([]byte(`{"initialized": false, "sealed": true, "standby": true}`))
+		case "/v1/sys/seal-status":
+			_, _ = w.Write([]byte(`{"initialized": false, "sealed": true}`))
+		case "/v1/sys/storage/raft/join":
+			join := map[string]interface{}{}
+			_ = json.NewDecoder(req.Body).Decode(&join)
+			joins = append(joins, join)
+			_, _ = w.Write([]byte(`{"joined": true}`))
+		case "/v1/sys/init":
+			initialized = true
+			_, _ = w.Write([]byte(`{"keys": ["key"], "keys_base64": ["a2V5"], "root_token": "root"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer fakeVault.Close()
+
+	podAddress := vaultPodAddress
+	vaultPodAddress = func(*vaultv1alpha1.Vault, string) string { return fakeVault.URL }
+	defer func() { vaultPodAddress = podAddress }()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`),
+			},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{OperatorUnseal: true},
+		},
+	}
+	f := &vaultv1alpha1.VaultFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-dc", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultFederationSpec{
+			VaultName:    "vault",
+			LocalCluster: "secondary",
+			Members: []vaultv1alpha1.VaultFederationMember{
+				{Name: "primary", Address: "vault.primary.example.com"},
+				{Name: "secondary"},
+			},
+		},
+		Status: vaultv1alpha1.VaultFederationStatus{
+			LeaderAddress:      "vault.primary.example.com",
+			LeaderCASecretName: "multi-dc-leader-ca",
+		},
+	}
+	leaderCA := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-dc-leader-ca", Namespace: "default"},
+		Data:       map[string][]byte{"ca.crt": []byte("leader-ca")},
+	}
+
+	r, c := newTestReconciler(v, f, leaderCA)
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(v)})
+	require.NoError(t, err)
+
+	// The follower joins the leader of the other cluster, instead of initializing a raft cluster of its own
+	assert.False(t, initialized)
+	require.Len(t, joins, 1)
+	assert.Equal(t, "http://vault.primary.example.com:8200", joins[0]["leader_api_addr"])
+	assert.Equal(t, "leader-ca", joins[0]["leader_ca_cert"])
+
+	current := &vaultv1alpha1.Vault{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), current))
+	assert.Empty(t, current.Spec.RaftLeaderAddress)
+}
@@ -0,0 +1,136 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Masterminds/semver/v3"
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// versionHistoryLimit is the number of versions kept in the version history
+const versionHistoryLimit = 10
+
+// checkVersionChange returns why changing the running Vault version to the requested one is refused,
+// or an empty string if it is allowed
+func checkVersionChange(running, requested *semver.Version, policy *vaultv1alpha1.VersionPolicy) string {
+	if policy == nil {
+		policy = &vaultv1alpha1.VersionPolicy{}
+	}
+
+	if requested.LessThan(running) && !policy.AllowDowngrade {
+		return fmt.Sprintf("downgrading vault from %s to %s is refused, set versionPolicy.allowDowngrade to force it", running, requested)
+	}
+
+	if requested.GreaterThan(running) && !policy.AllowSkipVersions &&
+		(requested.Major() != running.Major() || requested.Minor() > running.Minor()+1) {
+		return fmt.Sprintf("upgrading vault from %s to %s skips versions and is refused, set versionPolicy.allowSkipVersions to force it", running, requested)
+	}
+
+	return ""
+}
+
+// guardVaultVersion keeps the running image if the requested one would downgrade Vault or skip versions,
+// it has to run before anything is derived from the Vault image. The spec is only changed in memory.
+func (r *ReconcileVault) guardVaultVersion(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	status := &vaultv1alpha1.VersionStatus{}
+	if v.Status.Version != nil {
+		status = v.Status.Version.DeepCopy()
+	}
+	status.Message = ""
+
+	requested, err := v.Spec.GetVersion()
+	if err != nil {
+		status.Message = fmt.Sprintf("the version of image %s is unknown (%v), pin a version tag to enable the version guardrails", v.Spec.GetVaultImage(), err)
+	} else if running, err := semver.NewVersion(status.Running); status.Running != "" && err == nil {
+		if refusal := checkVersionChange(running, requested, v.Spec.VersionPolicy); refusal != "" {
+			current := &appsv1.StatefulSet{}
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: v.Name}, current)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get StatefulSet: %v", err)
+			}
+
+			if image := vaultContainerImage(current); image != "" {
+				v.Spec.Image = image
+				status.Message = fmt.Sprintf("%s, keeping %s", refusal, image)
+			}
+		}
+	}
+
+	if status.Message != "" && (v.Status.Version == nil || v.Status.Version.Message != status.Message) {
+		r.recorder.Event(v, corev1.EventTypeWarning, "VersionGuardrail", status.Message)
+	}
+
+	return r.setVersionStatus(ctx, v, status)
+}
+
+// recordVaultVersion records the version reported by the active Vault Pod in the version history
+func (r *ReconcileVault) recordVaultVersion(ctx context.Context, v *vaultv1alpha1.Vault, leader string, healths map[string]*api.HealthResponse) error {
+	health, ok := healths[leader]
+	if !ok || health.Sealed || health.Version == "" {
+		return nil
+	}
+
+	status := &vaultv1alpha1.VersionStatus{}
+	if v.Status.Version != nil {
+		status = v.Status.Version.DeepCopy()
+	}
+	if status.Running == health.Version {
+		return nil
+	}
+
+	entry := vaultv1alpha1.VersionHistoryEntry{Version: health.Version, Time: metav1.Now()}
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: leader}, pod); err == nil {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == "vault" {
+				entry.Image = container.Image
+			}
+		}
+	}
+
+	if status.Running != "" {
+		r.recorder.Eventf(v, corev1.EventTypeNormal, "VersionChanged", "vault runs %s, it was running %s", health.Version, status.Running)
+	}
+
+	status.Running = health.Version
+	status.History = append(status.History, entry)
+	if len(status.History) > versionHistoryLimit {
+		status.History = status.History[len(status.History)-versionHistoryLimit:]
+	}
+
+	return r.setVersionStatus(ctx, v, status)
+}
+
+func (r *ReconcileVault) setVersionStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.VersionStatus) error {
+	if reflect.DeepEqual(status, v.Status.Version) || (v.Status.Version == nil && reflect.DeepEqual(status, &vaultv1alpha1.VersionStatus{})) {
+		return nil
+	}
+
+	v.Status.Version = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.Version = status
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckVersionChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		running   string
+		requested string
+		policy    *vaultv1alpha1.VersionPolicy
+		refused   bool
+	}{
+		{name: "same version", running: "1.19.0", requested: "1.19.0"},
+		{name: "patch upgrade", running: "1.19.0", requested: "1.19.3"},
+		{name: "minor upgrade", running: "1.19.3", requested: "1.20.0"},
+		{name: "enterprise build", running: "1.19.0+ent", requested: "1.20.0"},
+		{name: "skipped minor", running: "1.18.0", requested: "1.20.0", refused: true},
+		{name: "major upgrade", running: "1.19.0", requested: "2.0.0", refused: true},
+		{
+			name: "allowed skip", running: "1.18.0", requested: "1.20.0",
+			policy: &vaultv1alpha1.VersionPolicy{AllowSkipVersions: true},
+		},
+		{name: "downgrade", running: "1.20.0", requested: "1.19.0", refused: true},
+		{
+			name: "allowed downgrade", running: "1.20.0", requested: "1.19.0",
+			policy: &vaultv1alpha1.VersionPolicy{AllowDowngrade: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refusal := checkVersionChange(semver.MustParse(test.running), semver.MustParse(test.requested), test.policy)
+			assert.Equal(t, test.refused, refusal != "", refusal)
+		})
+	}
+}
+
+func TestGuardVaultVersion(t *testing.T) {
+	ctx := context.Background()
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:  1,
+			Image: "hashicorp/vault:1.18.0",
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "vault", Image: "hashicorp/vault:1.19.0"}},
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "vault", Image: "hashicorp/vault:1.19.0"}},
+		},
+	}
+
+	reconciler, _ := newTestReconciler(v, statefulSet, pod)
+
+	// Nothing is known about the running version yet
+	require.NoError(t, reconciler.guardVaultVersion(ctx, v))
+	assert.Equal(t, "hashicorp/vault:1.18.0", v.Spec.Image)
+
+	healths := map[string]*api.HealthResponse{"vault-0": {Initialized: true, Version: "1.19.0"}}
+	require.NoError(t, reconciler.recordVaultVersion(ctx, v, "vault-0", healths))
+	require.NotNil(t, v.Status.Version)
+	assert.Equal(t, "1.19.0", v.Status.Version.Running)
+	require.Len(t, v.Status.Version.History, 1)
+	assert.Equal(t, "hashicorp/vault:1.19.0", v.Status.Version.History[0].Image)
+
+	// The downgrade is refused, the running image is kept
+	require.NoError(t, reconciler.guardVaultVersion(ctx, v))
+	assert.Equal(t, "hashicorp/vault:1.19.0", v.Spec.Image)
+	assert.Contains(t, v.Status.Version.Message, "downgrading vault from 1.19.0 to 1.18.0 is refused")
+
+	// Unpinned images are warned about
+	v.Spec.Image = "hashicorp/vault:latest"
+	require.NoError(t, reconciler.guardVaultVersion(ctx, v))
+	assert.Equal(t, "hashicorp/vault:latest", v.Spec.Image)
+	assert.Contains(t, v.Status.Version.Message, "pin a version tag")
+}
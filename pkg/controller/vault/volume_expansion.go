@@ -0,0 +1,230 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultStorageClassAnnotation marks the StorageClass of the claims without one
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// claimTemplateSizes returns the requested storage sizes of the volume claim templates by name
+func claimTemplateSizes(statefulSet *appsv1.StatefulSet) map[string]resource.Quantity {
+	sizes := map[string]resource.Quantity{}
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		sizes[template.Name] = template.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+	return sizes
+}
+
+// reconcileVolumeExpansion expands the claims of the Vault Pods if the size of a volume claim template has been
+// raised, and deletes the StatefulSet leaving its Pods running, so it is recreated with the new templates.
+// It returns true while the StatefulSet is being deleted.
+func (r *ReconcileVault) reconcileVolumeExpansion(ctx context.Context, v *vaultv1alpha1.Vault, statefulSet *appsv1.StatefulSet) (bool, error) {
+	current := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(statefulSet), current)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get StatefulSet: %v", err)
+	}
+
+	// The orphaned Pods are adopted by the new StatefulSet once the old one is gone
+	if current.DeletionTimestamp != nil {
+		return true, nil
+	}
+
+	currentSizes := claimTemplateSizes(current)
+	var claims []vaultv1alpha1.ClaimExpansionStatus
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		name := template.Name
+		size := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		currentSize, ok := currentSizes[name]
+		if !ok || size.Cmp(currentSize) == 0 {
+			continue
+		}
+
+		if size.Cmp(currentSize) < 0 {
+			statefulSet.Spec.VolumeClaimTemplates = current.Spec.VolumeClaimTemplates
+			return false, r.failVolumeExpansion(ctx, v, fmt.Sprintf("volume claim template %s can't shrink from %s to %s", name, currentSize.String(), size.String()))
+		}
+
+		for i := 0; i < int(v.Spec.Size); i++ {
+			claim := &corev1.PersistentVolumeClaim{}
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: fmt.Sprintf("%s-%s-%d", name, v.Name, i)}, claim)
+			if apierrors.IsNotFound(err) {
+				// Claims created later get the new size from the template
+				continue
+			} else if err != nil {
+				return false, fmt.Errorf("failed to get PersistentVolumeClaim: %v", err)
+			}
+
+			expandable, err := r.claimExpandable(ctx, claim)
+			if err != nil {
+				return false, err
+			}
+			if !expandable {
+				statefulSet.Spec.VolumeClaimTemplates = current.Spec.VolumeClaimTemplates
+				return false, r.failVolumeExpansion(ctx, v, fmt.Sprintf("the StorageClass of PersistentVolumeClaim %s doesn't allow volume expansion", claim.Name))
+			}
+
+			claims = append(claims, vaultv1alpha1.ClaimExpansionStatus{Name: claim.Name, Requested: size.String()})
+		}
+	}
+
+	if len(claims) == 0 {
+		// The templates match again, the failure is over
+		if v.Status.VolumeExpansion != nil && v.Status.VolumeExpansion.Phase == vaultv1alpha1.VolumeExpansionFailed {
+			return false, r.setVolumeExpansionStatus(ctx, v, nil)
+		}
+		return false, nil
+	}
+
+	for _, status := range claims {
+		claim := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: status.Name}, claim); err != nil {
+			return false, fmt.Errorf("failed to get PersistentVolumeClaim: %v", err)
+		}
+
+		size := resource.MustParse(status.Requested)
+		if requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]; requested.Cmp(size) >= 0 {
+			continue
+		}
+
+		patch := client.MergeFrom(claim.DeepCopy())
+		if claim.Spec.Resources.Requests == nil {
+			claim.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		claim.Spec.Resources.Requests[corev1.ResourceStorage] = size
+		if err := r.client.Patch(ctx, claim, patch); err != nil {
+			return false, fmt.Errorf("failed to expand PersistentVolumeClaim %s: %v", claim.Name, err)
+		}
+	}
+
+	if err := r.recreateStatefulSet(ctx, v, current, "the volume claim templates have been resized"); err != nil {
+		return false, err
+	}
+
+	return true, r.setVolumeExpansionStatus(ctx, v, &vaultv1alpha1.VolumeExpansionStatus{
+		Phase:   vaultv1alpha1.VolumeExpansionExpanding,
+		Claims:  claims,
+		Message: "the PersistentVolumeClaims have been patched, waiting for their capacity",
+	})
+}
+
+// reconcileVolumeExpansionProgress reports the capacity of the expanded claims,
+// it returns true until every claim has been expanded
+func (r *ReconcileVault) reconcileVolumeExpansionProgress(ctx context.Context, v *vaultv1alpha1.Vault) (bool, error) {
+	if v.Status.VolumeExpansion == nil || v.Status.VolumeExpansion.Phase != vaultv1alpha1.VolumeExpansionExpanding {
+		return false, nil
+	}
+
+	status := v.Status.VolumeExpansion.DeepCopy()
+	expanded := true
+	for i, claimStatus := range status.Claims {
+		claim := &corev1.PersistentVolumeClaim{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: v.Namespace, Name: claimStatus.Name}, claim)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get PersistentVolumeClaim: %v", err)
+		}
+
+		capacity := claim.Status.Capacity[corev1.ResourceStorage]
+		status.Claims[i].Capacity = capacity.String()
+		if capacity.Cmp(resource.MustParse(claimStatus.Requested)) < 0 {
+			expanded = false
+		}
+	}
+
+	if expanded {
+		status.Phase = vaultv1alpha1.VolumeExpansionCompleted
+		status.Message = ""
+		r.recorder.Event(v, corev1.EventTypeNormal, "VolumesExpanded", "the PersistentVolumeClaims have been expanded")
+	}
+
+	return !expanded, r.setVolumeExpansionStatus(ctx, v, status)
+}
+
+// claimExpandable tells whether the StorageClass of the claim allows volume expansion
+func (r *ReconcileVault) claimExpandable(ctx context.Context, claim *corev1.PersistentVolumeClaim) (bool, error) {
+	var storageClass *storagev1.StorageClass
+
+	if name := claim.Spec.StorageClassName; name != nil && *name != "" {
+		storageClass = &storagev1.StorageClass{}
+		err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Name: *name}, storageClass)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get StorageClass: %v", err)
+		}
+	} else {
+		storageClasses := &storagev1.StorageClassList{}
+		if err := r.nonNamespacedClient.List(ctx, storageClasses); err != nil {
+			return false, fmt.Errorf("failed to list StorageClasses: %v", err)
+		}
+		for i := range storageClasses.Items {
+			if storageClasses.Items[i].Annotations[defaultStorageClassAnnotation] == "true" {
+				storageClass = &storageClasses.Items[i]
+			}
+		}
+	}
+
+	return storageClass != nil && storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
+func (r *ReconcileVault) failVolumeExpansion(ctx context.Context, v *vaultv1alpha1.Vault, message string) error {
+	if v.Status.VolumeExpansion == nil || v.Status.VolumeExpansion.Message != message {
+		r.recorder.Event(v, corev1.EventTypeWarning, "VolumeExpansionFailed", message)
+	}
+
+	return r.setVolumeExpansionStatus(ctx, v, &vaultv1alpha1.VolumeExpansionStatus{
+		Phase:   vaultv1alpha1.VolumeExpansionFailed,
+		Message: message,
+	})
+}
+
+func (r *ReconcileVault) setVolumeExpansionStatus(ctx context.Context, v *vaultv1alpha1.Vault, status *vaultv1alpha1.VolumeExpansionStatus) error {
+	old := v.Status.VolumeExpansion
+	if status != nil {
+		if old != nil && old.Phase == status.Phase {
+			status.LastTransitionTime = old.LastTransitionTime
+		} else {
+			status.LastTransitionTime = metav1.Now()
+		}
+	}
+
+	if reflect.DeepEqual(status, old) {
+		return nil
+	}
+
+	v.Status.VolumeExpansion = status
+
+	return r.updateVault(ctx, client.ObjectKeyFromObject(v), func(v *vaultv1alpha1.Vault) {
+		v.Status.VolumeExpansion = status
+	})
+}
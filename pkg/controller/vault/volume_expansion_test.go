@@ -0,0 +1,146 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestVolumeExpansion(t *testing.T) {
+	ctx := context.Background()
+
+	statefulSetWithClaimSize := func(size string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-raft"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		size       string
+		expandable bool
+		recreated  bool
+		phase      vaultv1alpha1.VolumeExpansionPhase
+	}{
+		{name: "expansion", size: "2Gi", expandable: true, recreated: true, phase: vaultv1alpha1.VolumeExpansionExpanding},
+		{name: "not expandable", size: "2Gi", phase: vaultv1alpha1.VolumeExpansionFailed},
+		{name: "shrink", size: "512Mi", expandable: true, phase: vaultv1alpha1.VolumeExpansionFailed},
+		{name: "unchanged", size: "1Gi", expandable: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &vaultv1alpha1.Vault{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+				Spec:       vaultv1alpha1.VaultSpec{Size: 3},
+			}
+			objects := []client.Object{
+				v,
+				statefulSetWithClaimSize("1Gi"),
+				&storagev1.StorageClass{
+					ObjectMeta:           metav1.ObjectMeta{Name: "standard", Annotations: map[string]string{defaultStorageClassAnnotation: "true"}},
+					AllowVolumeExpansion: ptr.To(test.expandable),
+				},
+			}
+			for i := 0; i < 3; i++ {
+				objects = append(objects, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("vault-raft-vault-%d", i), Namespace: "default"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+					},
+				})
+			}
+
+			r, c := newTestReconciler(objects...)
+
+			statefulSet := statefulSetWithClaimSize(test.size)
+			recreated, err := r.reconcileVolumeExpansion(ctx, v, statefulSet)
+			require.NoError(t, err)
+			assert.Equal(t, test.recreated, recreated)
+
+			err = c.Get(ctx, client.ObjectKeyFromObject(statefulSet), &appsv1.StatefulSet{})
+			assert.Equal(t, test.recreated, apierrors.IsNotFound(err))
+
+			updated := &vaultv1alpha1.Vault{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), updated))
+			if test.phase == "" {
+				assert.Nil(t, updated.Status.VolumeExpansion)
+				return
+			}
+			require.NotNil(t, updated.Status.VolumeExpansion)
+			assert.Equal(t, test.phase, updated.Status.VolumeExpansion.Phase)
+
+			if !test.recreated {
+				// The current templates are kept, so the StatefulSet can be updated
+				assert.Equal(t, "1Gi", statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+				return
+			}
+
+			assert.Len(t, updated.Status.VolumeExpansion.Claims, 3)
+			for i := 0; i < 3; i++ {
+				claim := &corev1.PersistentVolumeClaim{}
+				require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: fmt.Sprintf("vault-raft-vault-%d", i)}, claim))
+				assert.Equal(t, "2Gi", claim.Spec.Resources.Requests.Storage().String())
+
+				// Not every claim is expanded yet
+				if i < 2 {
+					claim.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+					require.NoError(t, c.Status().Update(ctx, claim))
+				}
+			}
+
+			pending, err := r.reconcileVolumeExpansionProgress(ctx, updated)
+			require.NoError(t, err)
+			assert.True(t, pending)
+
+			claim := &corev1.PersistentVolumeClaim{}
+			require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-raft-vault-2"}, claim))
+			claim.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+			require.NoError(t, c.Status().Update(ctx, claim))
+
+			pending, err = r.reconcileVolumeExpansionProgress(ctx, updated)
+			require.NoError(t, err)
+			assert.False(t, pending)
+			assert.Equal(t, vaultv1alpha1.VolumeExpansionCompleted, updated.Status.VolumeExpansion.Phase)
+			assert.Equal(t, "2Gi", updated.Status.VolumeExpansion.Claims[2].Capacity)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultfederation
+
+import (
+	"context"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("VaultFederation across clusters", func() {
+	ctx := context.Background()
+
+	newVault := func() *vaultv1alpha1.Vault {
+		return &vaultv1alpha1.Vault{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+			Spec: vaultv1alpha1.VaultSpec{
+				Size:   1,
+				Config: extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`)},
+			},
+		}
+	}
+
+	newFederation := func(localCluster string) *vaultv1alpha1.VaultFederation {
+		return &vaultv1alpha1.VaultFederation{
+			ObjectMeta: metav1.ObjectMeta{Name: "multi-dc", Namespace: "default"},
+			Spec: vaultv1alpha1.VaultFederationSpec{
+				VaultName:    "vault",
+				LocalCluster: localCluster,
+				Members: []vaultv1alpha1.VaultFederationMember{
+					{
+						Name:    "primary",
+						Address: "vault.primary.example.com",
+						KubeconfigSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "primary-kubeconfig"},
+							Key:                  "kubeconfig",
+						},
+					},
+					{Name: "secondary"},
+				},
+			},
+		}
+	}
+
+	newReconciler := func(c client.Client, scheme *runtime.Scheme) *ReconcileVaultFederation {
+		return &ReconcileVaultFederation{client: c, scheme: scheme, recorder: record.NewFakeRecorder(10)}
+	}
+
+	It("points the follower to the bootstrap leader of the other cluster", func() {
+		By("creating the members in both clusters")
+		Expect(primaryClient.Create(ctx, newVault())).To(Succeed())
+		Expect(primaryClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-tls", Namespace: "default"},
+			Data:       map[string][]byte{"ca.crt": []byte("primary-ca")},
+		})).To(Succeed())
+		Expect(primaryClient.Create(ctx, newFederation("primary"))).To(Succeed())
+
+		secondaryVault := newVault()
+		Expect(secondaryClient.Create(ctx, secondaryVault)).To(Succeed())
+		Expect(secondaryClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "primary-kubeconfig", Namespace: "default"},
+			Data:       map[string][]byte{"kubeconfig": primaryKubeconfig},
+		})).To(Succeed())
+		Expect(secondaryClient.Create(ctx, newFederation("secondary"))).To(Succeed())
+
+		request := reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "multi-dc"}}
+
+		By("reconciling the follower before the leader has published its member Secret")
+		secondary := newReconciler(secondaryClient, scheme)
+		_, err := secondary.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		f := &vaultv1alpha1.VaultFederation{}
+		Expect(secondaryClient.Get(ctx, request.NamespacedName, f)).To(Succeed())
+		Expect(f.Status.LeaderAddress).To(BeEmpty())
+		Expect(f.Status.Members[0].Message).To(Equal("member secret is not published yet"))
+
+		By("reconciling the bootstrap leader")
+		primary := newReconciler(primaryClient, scheme)
+		_, err = primary.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(primaryClient.Get(ctx, request.NamespacedName, f)).To(Succeed())
+		Expect(f.Status.LeaderAddress).To(Equal("vault.primary.example.com"))
+		Expect(f.Status.LeaderCASecretName).To(BeEmpty())
+
+		By("reconciling the follower again")
+		_, err = secondary.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(secondaryClient.Get(ctx, request.NamespacedName, f)).To(Succeed())
+		Expect(f.Status.LeaderAddress).To(Equal("vault.primary.example.com"))
+		Expect(f.Status.LeaderCASecretName).To(Equal("multi-dc-leader-ca"))
+		Expect(f.Status.Members).To(HaveLen(2))
+		Expect(f.Status.Members[0].LeaderAddress).To(Equal("vault.primary.example.com"))
+
+		ca := &corev1.Secret{}
+		Expect(secondaryClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "multi-dc-leader-ca"}, ca)).To(Succeed())
+		Expect(ca.Data["ca.crt"]).To(Equal([]byte("primary-ca")))
+
+		By("leaving the Vault CR of the follower to its owner")
+		v := &vaultv1alpha1.Vault{}
+		Expect(secondaryClient.Get(ctx, client.ObjectKeyFromObject(secondaryVault), v)).To(Succeed())
+		Expect(v.Spec.RaftLeaderAddress).To(BeEmpty())
+		Expect(v.Spec.Volumes).To(BeEmpty())
+		Expect(v.Generation).To(Equal(secondaryVault.Generation))
+	})
+})
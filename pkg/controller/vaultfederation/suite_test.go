@@ -0,0 +1,87 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultfederation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// These tests run a federation across two API servers, each one standing for a member cluster.
+// They need the envtest binaries, see the test target of the Makefile.
+
+var scheme = runtime.NewScheme()
+
+// primaryEnv and secondaryEnv are the member clusters, the kubeconfig of primaryEnv is stored in secondaryEnv
+var primaryEnv, secondaryEnv *envtest.Environment
+var primaryClient, secondaryClient client.Client
+var primaryKubeconfig []byte
+
+func TestMultiCluster(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "VaultFederation Multi-Cluster Suite")
+}
+
+var _ = BeforeSuite(func() {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(vaultv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	By("bootstrapping the member clusters")
+	crdPaths := []string{filepath.Join("..", "..", "..", "deploy", "crd", "bases")}
+	primaryEnv = &envtest.Environment{CRDDirectoryPaths: crdPaths, ErrorIfCRDPathMissing: true}
+	secondaryEnv = &envtest.Environment{CRDDirectoryPaths: crdPaths, ErrorIfCRDPathMissing: true}
+
+	primaryConfig, err := primaryEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	secondaryConfig, err := secondaryEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+
+	primaryClient, err = client.New(primaryConfig, client.Options{Scheme: scheme})
+	Expect(err).NotTo(HaveOccurred())
+	secondaryClient, err = client.New(secondaryConfig, client.Options{Scheme: scheme})
+	Expect(err).NotTo(HaveOccurred())
+
+	user, err := primaryEnv.AddUser(envtest.User{Name: "vault-federation", Groups: []string{"system:masters"}}, nil)
+	Expect(err).NotTo(HaveOccurred())
+	primaryKubeconfig, err = user.KubeConfig()
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	By("tearing down the member clusters")
+	for _, env := range []*envtest.Environment{primaryEnv, secondaryEnv} {
+		if env != nil {
+			Expect(env.Stop()).To(Succeed())
+		}
+	}
+})
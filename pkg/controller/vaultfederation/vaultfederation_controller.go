@@ -0,0 +1,381 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultfederation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// The keys of the member Secrets published by each member of the federation
+	leaderAddressKey = "leaderAddress"
+	caCertKey        = "ca.crt"
+	readyKey         = "ready"
+
+	// Remote members are not watched, so their state is polled
+	resyncPeriod = 30 * time.Second
+)
+
+var log = logf.Log.WithName("controller_vaultfederation")
+
+// Add creates a new VaultFederation Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileVaultFederation{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("vaultfederation-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("vaultfederation-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource VaultFederation
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.VaultFederation{}, &handler.TypedEnqueueRequestForObject[*vaultv1alpha1.VaultFederation]{}))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileVaultFederation{}
+
+// ReconcileVaultFederation reconciles a VaultFederation object
+type ReconcileVaultFederation struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// memberClients caches the clients of the remote members, see clientForMember
+	memberClientsLock sync.Mutex
+	memberClients     map[string]memberClient
+}
+
+// memberClient is the client of a remote member, built from a given version of its kubeconfig Secret
+type memberClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+// Reconcile publishes the state of the local federation member, and records the address and CA bundle
+// of the bootstrap leader in the status, which the Vault controller applies to the Pods of the local Vault.
+func (r *ReconcileVaultFederation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling VaultFederation")
+
+	f := &vaultv1alpha1.VaultFederation{}
+	err := r.client.Get(ctx, request.NamespacedName, f)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if err := f.Spec.Validate(); err != nil {
+		r.recorder.Event(f, corev1.EventTypeWarning, "InvalidFederation", err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	v := &vaultv1alpha1.Vault{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: f.Namespace, Name: f.Spec.VaultName}, v)
+	if apierrors.IsNotFound(err) {
+		reqLogger.Info("waiting for the local Vault to be created", "vault", f.Spec.VaultName)
+		return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	memberSecret, err := r.memberSecretForVault(ctx, f, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := controllerutil.SetControllerReference(f, memberSecret, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := createOrUpdateSecret(ctx, r.client, memberSecret); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to publish federation member secret: %v", err)
+	}
+
+	members := map[string]*corev1.Secret{f.Spec.LocalCluster: memberSecret}
+	memberErrors := map[string]error{}
+	for _, member := range f.Spec.Members {
+		if member.Name == f.Spec.LocalCluster {
+			continue
+		}
+		secret, err := r.getMemberSecret(ctx, f, member)
+		if err != nil {
+			memberErrors[member.Name] = err
+			continue
+		}
+		members[member.Name] = secret
+	}
+
+	leaderAddress := ""
+	leaderCASecretName := ""
+	if leader := members[f.Spec.GetBootstrapCluster()]; leader != nil {
+		leaderAddress = string(leader.Data[leaderAddressKey])
+
+		if !f.Spec.IsBootstrapLeader() && len(leader.Data[caCertKey]) != 0 {
+			leaderCASecretName, err = r.publishLeaderCA(ctx, f, leader.Data[caCertKey])
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if err := r.updateFederationStatus(ctx, f, leaderAddress, leaderCASecretName, members, memberErrors); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update vault federation status: %v", err)
+	}
+
+	return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+func memberSecretName(f *vaultv1alpha1.VaultFederation, member string) string {
+	return f.Name + "-member-" + member
+}
+
+func leaderCASecretName(f *vaultv1alpha1.VaultFederation) string {
+	return f.Name + "-leader-ca"
+}
+
+// memberSecretForVault returns the Secret which publishes the address, CA bundle and readiness of the local member
+func (r *ReconcileVaultFederation) memberSecretForVault(ctx context.Context, f *vaultv1alpha1.VaultFederation, v *vaultv1alpha1.Vault) (*corev1.Secret, error) {
+	data := map[string][]byte{
+		leaderAddressKey: []byte(f.Spec.GetMember(f.Spec.LocalCluster).Address),
+		readyKey:         []byte(strconv.FormatBool(isVaultReady(v))),
+	}
+
+	if !v.Spec.IsTLSDisabled() {
+		tlsSecretName := v.Name + "-tls"
+		if v.Spec.ExistingTLSSecretName != "" {
+			tlsSecretName = v.Spec.ExistingTLSSecretName
+		}
+
+		tlsSecret := &corev1.Secret{}
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: v.Namespace, Name: tlsSecretName}, tlsSecret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get vault tls secret: %v", err)
+		}
+
+		if ca := tlsSecret.Data[caCertKey]; len(ca) != 0 {
+			data[caCertKey] = ca
+		}
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      memberSecretName(f, f.Spec.LocalCluster),
+			Namespace: f.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":           "vault-federation",
+				"vault.banzaicloud.com/federation": f.Name,
+				"vault.banzaicloud.com/member":     f.Spec.LocalCluster,
+			},
+		},
+		Data: data,
+	}, nil
+}
+
+// isVaultReady checks if all instances of the Vault answered the last health check of the Vault controller
+func isVaultReady(v *vaultv1alpha1.Vault) bool {
+	return len(v.Status.Conditions) > 0 && v.Status.Conditions[0].Error == "" && len(v.Status.Nodes) == int(v.Spec.Size)
+}
+
+// getMemberSecret reads the Secret published by a remote member of the federation
+func (r *ReconcileVaultFederation) getMemberSecret(ctx context.Context, f *vaultv1alpha1.VaultFederation, member vaultv1alpha1.VaultFederationMember) (*corev1.Secret, error) {
+	memberClient := r.client
+	if member.KubeconfigSecretRef != nil {
+		var err error
+		memberClient, err = r.clientForMember(ctx, f, member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespace := member.Namespace
+	if namespace == "" {
+		namespace = f.Namespace
+	}
+
+	secret := &corev1.Secret{}
+	err := memberClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: memberSecretName(f, member.Name)}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("member secret is not published yet")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get member secret: %v", err)
+	}
+
+	return secret, nil
+}
+
+// clientForMember returns a client of a remote member, the clients are cached until the kubeconfig Secret changes
+func (r *ReconcileVaultFederation) clientForMember(ctx context.Context, f *vaultv1alpha1.VaultFederation, member vaultv1alpha1.VaultFederationMember) (client.Client, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: f.Namespace, Name: member.KubeconfigSecretRef.Name}, kubeconfigSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret: %v", err)
+	}
+
+	cacheKey := f.Namespace + "/" + member.KubeconfigSecretRef.Name + "/" + member.KubeconfigSecretRef.Key
+
+	r.memberClientsLock.Lock()
+	defer r.memberClientsLock.Unlock()
+
+	if cached, ok := r.memberClients[cacheKey]; ok && cached.resourceVersion == kubeconfigSecret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	kubeconfig, ok := kubeconfigSecret.Data[member.KubeconfigSecretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig secret %s has no key %s", member.KubeconfigSecretRef.Name, member.KubeconfigSecretRef.Key)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %v", err)
+	}
+
+	memberClusterClient, err := client.New(config, client.Options{Scheme: r.scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %v", err)
+	}
+
+	if r.memberClients == nil {
+		r.memberClients = map[string]memberClient{}
+	}
+	r.memberClients[cacheKey] = memberClient{resourceVersion: kubeconfigSecret.ResourceVersion, client: memberClusterClient}
+
+	return memberClusterClient, nil
+}
+
+// publishLeaderCA stores the CA bundle of the bootstrap leader in a local Secret, which the Vault controller
+// mounts into the bank-vaults sidecar joining the Vault instances to the raft cluster, and returns its name
+func (r *ReconcileVaultFederation) publishLeaderCA(ctx context.Context, f *vaultv1alpha1.VaultFederation, leaderCA []byte) (string, error) {
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaderCASecretName(f),
+			Namespace: f.Namespace,
+		},
+		Data: map[string][]byte{caCertKey: leaderCA},
+	}
+
+	if err := controllerutil.SetControllerReference(f, caSecret, r.scheme); err != nil {
+		return "", err
+	}
+
+	if err := createOrUpdateSecret(ctx, r.client, caSecret); err != nil {
+		return "", fmt.Errorf("failed to create/update federation leader ca secret: %v", err)
+	}
+
+	return caSecret.Name, nil
+}
+
+// updateFederationStatus records the membership of each cluster, as seen from the local cluster
+func (r *ReconcileVaultFederation) updateFederationStatus(ctx context.Context, f *vaultv1alpha1.VaultFederation, leaderAddress, leaderCASecretName string, members map[string]*corev1.Secret, memberErrors map[string]error) error {
+	status := vaultv1alpha1.VaultFederationStatus{
+		BootstrapCluster:   f.Spec.GetBootstrapCluster(),
+		LeaderAddress:      leaderAddress,
+		LeaderCASecretName: leaderCASecretName,
+	}
+
+	for _, member := range f.Spec.Members {
+		memberStatus := vaultv1alpha1.VaultFederationMemberStatus{Name: member.Name}
+
+		if secret := members[member.Name]; secret != nil {
+			memberStatus.LeaderAddress = string(secret.Data[leaderAddressKey])
+			memberStatus.Ready, _ = strconv.ParseBool(string(secret.Data[readyKey]))
+			if !memberStatus.Ready {
+				memberStatus.Message = "vault is not ready"
+			}
+		} else if err := memberErrors[member.Name]; err != nil {
+			memberStatus.Message = err.Error()
+		}
+
+		// Only bump the update time if the observed state of the member has changed
+		memberStatus.LastUpdateTime = metav1.Now()
+		for _, previous := range f.Status.Members {
+			if previous.Name == memberStatus.Name {
+				previousTime := previous.LastUpdateTime
+				previous.LastUpdateTime = memberStatus.LastUpdateTime
+				if reflect.DeepEqual(previous, memberStatus) {
+					memberStatus.LastUpdateTime = previousTime
+				}
+			}
+		}
+
+		status.Members = append(status.Members, memberStatus)
+	}
+
+	if reflect.DeepEqual(status, f.Status) {
+		return nil
+	}
+
+	f.Status = status
+	return r.client.Update(ctx, f)
+}
+
+func createOrUpdateSecret(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	current := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(secret), current)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, secret)
+	} else if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(current.Data, secret.Data) && reflect.DeepEqual(current.Labels, secret.Labels) {
+		return nil
+	}
+
+	secret.ResourceVersion = current.ResourceVersion
+	return c.Update(ctx, secret)
+}
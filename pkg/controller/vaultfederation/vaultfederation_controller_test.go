@@ -0,0 +1,155 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultfederation
+
+import (
+	"context"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/bank-vaults/vault-operator/pkg/controller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newFederation(localCluster string) *vaultv1alpha1.VaultFederation {
+	return &vaultv1alpha1.VaultFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-dc", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultFederationSpec{
+			VaultName:    "vault",
+			LocalCluster: localCluster,
+			Members: []vaultv1alpha1.VaultFederationMember{
+				{Name: "primary", Address: "vault.primary.example.com"},
+				{Name: "secondary"},
+			},
+		},
+	}
+}
+
+func newTestReconciler(objects ...client.Object) (*ReconcileVaultFederation, client.Client) {
+	c, scheme := testutil.NewFakeClient(objects...)
+
+	return &ReconcileVaultFederation{client: c, scheme: scheme, recorder: record.NewFakeRecorder(10)}, c
+}
+
+func TestValidateFederation(t *testing.T) {
+	f := newFederation("secondary")
+	require.NoError(t, f.Spec.Validate())
+	assert.False(t, f.Spec.IsBootstrapLeader())
+
+	// The in-cluster address of the leader can't be reached from the other clusters
+	f.Spec.Members[0].Address = ""
+	assert.Error(t, f.Spec.Validate())
+
+	f = newFederation("tertiary")
+	assert.Error(t, f.Spec.Validate())
+}
+
+func TestReconcileFollower(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFederation("secondary")
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:              1,
+			SidecarEnvsConfig: []corev1.EnvVar{{Name: "VAULT_LOG_LEVEL", Value: "debug"}},
+		},
+	}
+	// The member Secret of the leader is synchronized into the local cluster by other means
+	leader := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-dc-member-primary", Namespace: "default"},
+		Data: map[string][]byte{
+			leaderAddressKey: []byte("vault.primary.example.com"),
+			caCertKey:        []byte("leader-ca"),
+			readyKey:         []byte("true"),
+		},
+	}
+
+	r, c := newTestReconciler(f, v, leader)
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(f)})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(f), f))
+	assert.Equal(t, "vault.primary.example.com", f.Status.LeaderAddress)
+	assert.Equal(t, "multi-dc-leader-ca", f.Status.LeaderCASecretName)
+	require.Len(t, f.Status.Members, 2)
+	assert.True(t, f.Status.Members[0].Ready)
+
+	ca := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "multi-dc-leader-ca"}, ca))
+	assert.Equal(t, []byte("leader-ca"), ca.Data[caCertKey])
+
+	published := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "multi-dc-member-secondary"}, published))
+	assert.Empty(t, published.Data[leaderAddressKey])
+
+	// The Vault CR is owned by the user, the federation is applied by the Vault controller
+	current := &vaultv1alpha1.Vault{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(v), current))
+	assert.Equal(t, v.Spec, current.Spec)
+}
+
+func TestClientForMemberIsCached(t *testing.T) {
+	ctx := context.Background()
+
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "primary-kubeconfig", Namespace: "default"},
+		Data: map[string][]byte{"kubeconfig": []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: primary
+  cluster:
+    server: https://primary.example.com:6443
+contexts:
+- name: primary
+  context:
+    cluster: primary
+    user: primary
+current-context: primary
+users:
+- name: primary
+  user:
+    token: secret
+`)},
+	}
+	f := newFederation("secondary")
+	f.Spec.Members[0].KubeconfigSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: kubeconfig.Name},
+		Key:                  "kubeconfig",
+	}
+
+	r, c := newTestReconciler(f, kubeconfig)
+
+	first, err := r.clientForMember(ctx, f, f.Spec.Members[0])
+	require.NoError(t, err)
+	second, err := r.clientForMember(ctx, f, f.Spec.Members[0])
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// A changed kubeconfig (e.g. rotated credentials) is picked up
+	kubeconfig.Data["kubeconfig"] = append(kubeconfig.Data["kubeconfig"], '\n')
+	require.NoError(t, c.Update(ctx, kubeconfig))
+	third, err := r.clientForMember(ctx, f, f.Spec.Members[0])
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+}
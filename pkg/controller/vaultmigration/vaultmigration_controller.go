@@ -0,0 +1,478 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	vaultcontroller "github.com/bank-vaults/vault-operator/pkg/controller/vault"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Migrations waiting for active Vault nodes are checked again after this period
+const pendingRetryPeriod = 30 * time.Second
+
+var log = logf.Log.WithName("controller_vaultmigration")
+
+// Add creates a new VaultMigration Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	reconciler, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, reconciler)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	nonNamespacedClient, err := client.New(mgr.GetConfig(), client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileVaultMigration{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		scheme:              mgr.GetScheme(),
+		recorder:            mgr.GetEventRecorderFor("vaultmigration-controller"),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("vaultmigration-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource VaultMigration
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.VaultMigration{}, &handler.TypedEnqueueRequestForObject[*vaultv1alpha1.VaultMigration]{}))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileVaultMigration{}
+
+// ReconcileVaultMigration reconciles a VaultMigration object
+type ReconcileVaultMigration struct {
+	client client.Client
+
+	// nonNamespacedClient reads the Vaults, tokens and unseal keys, and writes the alias Service,
+	// which may be in other namespaces
+	nonNamespacedClient client.Client
+
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile restores a raft snapshot of the source Vault into the target Vault, hibernates the source, copies
+// the unseal keys, and switches the alias Service over to the target once it is active.
+func (r *ReconcileVaultMigration) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling VaultMigration")
+
+	m := &vaultv1alpha1.VaultMigration{}
+	err := r.client.Get(ctx, request.NamespacedName, m)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch m.Status.Phase {
+	case vaultv1alpha1.VaultMigrationCompleted, vaultv1alpha1.VaultMigrationFailed:
+		return reconcile.Result{}, nil
+	}
+
+	sourceVault, err := r.getVault(ctx, m, m.Spec.Source)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	targetVault, err := r.getVault(ctx, m, m.Spec.Target)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if sourceVault == nil || targetVault == nil {
+		return reconcile.Result{}, r.fail(ctx, m, "the source or the target vault is not found")
+	}
+
+	if m.Status.Phase == vaultv1alpha1.VaultMigrationRestored {
+		return r.switchOver(ctx, m, sourceVault, targetVault)
+	}
+
+	if message := checkCompatibility(sourceVault, targetVault); message != "" {
+		return reconcile.Result{}, r.fail(ctx, m, message)
+	}
+
+	sourceClient, err := vaultcontroller.ActiveVaultClient(ctx, r.nonNamespacedClient, sourceVault)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	targetClient, err := vaultcontroller.ActiveVaultClient(ctx, r.nonNamespacedClient, targetVault)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if sourceClient == nil || targetClient == nil {
+		if err := r.updateStatus(ctx, m, vaultv1alpha1.VaultMigrationStatus{
+			Phase:   vaultv1alpha1.VaultMigrationPending,
+			Message: "waiting for active nodes in the source and the target vault",
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	// The alias Service points to the source until the target is ready
+	if m.Spec.AliasService != nil {
+		if err := r.pointAliasService(ctx, m, sourceVault); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	sourceToken, err := r.vaultToken(ctx, sourceVault, m.Spec.Source.TokenSecretRef)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, fmt.Sprintf("failed to get the token of the source vault: %v", err))
+	}
+	targetToken, err := r.vaultToken(ctx, targetVault, m.Spec.Target.TokenSecretRef)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, fmt.Sprintf("failed to get the token of the target vault: %v", err))
+	}
+
+	// Once the snapshot is restored the target can only be unsealed with the keys of the source
+	if err := r.checkUnsealKeys(ctx, sourceVault, targetVault); err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, err.Error())
+	}
+
+	if m.Spec.KeepSourceRunning {
+		r.recorder.Eventf(m, corev1.EventTypeWarning, "SourceWritable", "vault %s/%s keeps running, the writes made after its snapshot are not migrated",
+			sourceVault.Namespace, sourceVault.Name)
+	}
+
+	sourceClient.SetToken(sourceToken)
+	targetClient.SetToken(targetToken)
+	snapshotBytes, err := transferSnapshot(ctx, sourceClient, targetClient)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, err.Error())
+	}
+
+	r.recorder.Eventf(m, corev1.EventTypeNormal, "SnapshotRestored", "the snapshot of vault %s/%s (%d bytes) is restored into vault %s/%s",
+		sourceVault.Namespace, sourceVault.Name, snapshotBytes, targetVault.Namespace, targetVault.Name)
+
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, r.updateStatus(ctx, m, vaultv1alpha1.VaultMigrationStatus{
+		Phase:         vaultv1alpha1.VaultMigrationRestored,
+		SnapshotBytes: snapshotBytes,
+		Message:       fmt.Sprintf("waiting for an active node of vault %s/%s with the restored data", targetVault.Namespace, targetVault.Name),
+	})
+}
+
+// transferSnapshot streams a raft snapshot of the source into the target, it may be too large to be held in memory.
+// The snapshot comes from another cluster, so the restore has to be forced.
+func transferSnapshot(ctx context.Context, sourceClient, targetClient *api.Client) (int64, error) {
+	reader, writer := io.Pipe()
+	snapshotErr := make(chan error, 1)
+	go func() {
+		err := sourceClient.Sys().RaftSnapshotWithContext(ctx, writer)
+		_ = writer.CloseWithError(err)
+		snapshotErr <- err
+	}()
+
+	snapshot := &countingReader{reader: reader}
+	restoreErr := targetClient.Sys().RaftSnapshotRestoreWithContext(ctx, snapshot, true)
+
+	// A failed restore leaves the rest of the snapshot unread
+	_ = reader.CloseWithError(restoreErr)
+	if err := <-snapshotErr; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return 0, fmt.Errorf("failed to save the snapshot of the source vault: %v", err)
+	}
+	if restoreErr != nil {
+		return 0, fmt.Errorf("failed to restore the snapshot into the target vault: %v", restoreErr)
+	}
+
+	return snapshot.count, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+// switchOver pauses the source, copies its unseal keys to the target, and points the alias Service to the target
+// once it is active with the restored data. Every step is retried until it succeeds, since the restore is done.
+func (r *ReconcileVaultMigration) switchOver(ctx context.Context, m *vaultv1alpha1.VaultMigration, sourceVault, targetVault *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	// The writes to the source wouldn't be migrated anymore, so they are refused from now on
+	if !m.Spec.KeepSourceRunning && !sourceVault.Spec.Hibernate {
+		if err := r.hibernate(ctx, sourceVault); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(m, corev1.EventTypeNormal, "SourceHibernated", "vault %s/%s is hibernated, its data is in vault %s/%s",
+			sourceVault.Namespace, sourceVault.Name, targetVault.Namespace, targetVault.Name)
+	}
+
+	// The restored data is unsealed with the keys of the source from now on
+	if err := r.copyUnsealKeys(ctx, sourceVault, targetVault); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	targetClient, err := vaultcontroller.ActiveVaultClient(ctx, r.nonNamespacedClient, targetVault)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if targetClient == nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	message := fmt.Sprintf("vault %s/%s is active with the data of vault %s/%s, which is left hibernated",
+		targetVault.Namespace, targetVault.Name, sourceVault.Namespace, sourceVault.Name)
+	if m.Spec.KeepSourceRunning {
+		message = fmt.Sprintf("vault %s/%s is active with the data of vault %s/%s, which is left running",
+			targetVault.Namespace, targetVault.Name, sourceVault.Namespace, sourceVault.Name)
+	}
+
+	if m.Spec.AliasService != nil {
+		if err := r.pointAliasService(ctx, m, targetVault); err != nil {
+			return reconcile.Result{}, err
+		}
+		message = fmt.Sprintf("%s, service %s points to it", message, m.Spec.AliasService.Name)
+	}
+
+	r.recorder.Event(m, corev1.EventTypeNormal, "MigrationCompleted", message)
+
+	status := m.Status
+	status.Phase = vaultv1alpha1.VaultMigrationCompleted
+	status.Message = message
+	return reconcile.Result{}, r.updateStatus(ctx, m, status)
+}
+
+// hibernate scales the Vault down to zero Pods, its data is kept
+func (r *ReconcileVaultMigration) hibernate(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &vaultv1alpha1.Vault{}
+		if err := r.nonNamespacedClient.Get(ctx, client.ObjectKeyFromObject(v), current); err != nil {
+			return err
+		}
+		current.Spec.Hibernate = true
+		return r.nonNamespacedClient.Update(ctx, current)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hibernate vault %s/%s: %v", v.Namespace, v.Name, err)
+	}
+
+	v.Spec.Hibernate = true
+	return nil
+}
+
+// checkCompatibility returns why the snapshot of the source can't be restored into the target,
+// or an empty string if it can
+func checkCompatibility(sourceVault, targetVault *vaultv1alpha1.Vault) string {
+	if !sourceVault.Spec.IsRaftStorage() || !targetVault.Spec.IsRaftStorage() {
+		return "the source and the target vault have to use the raft storage backend"
+	}
+
+	// The restored keyring is encrypted by the root key of the source, which is protected by its seal
+	sourceSeal := sourceVault.Spec.GetVaultConfig()["seal"]
+	targetSeal := targetVault.Spec.GetVaultConfig()["seal"]
+	if !reflect.DeepEqual(sourceSeal, targetSeal) {
+		return "the target vault has to use the seal of the source, it can be migrated after the migration"
+	}
+
+	_, _, sourceKubernetes := sourceVault.Spec.UnsealConfig.GetKubernetesSecret(sourceVault)
+	_, _, targetKubernetes := targetVault.Spec.UnsealConfig.GetKubernetesSecret(targetVault)
+	if (!sourceKubernetes || !targetKubernetes) && !reflect.DeepEqual(sourceVault.Spec.UnsealConfig, targetVault.Spec.UnsealConfig) {
+		return "the unseal keys of the source can only be copied between Kubernetes Secrets, store the keys of the target with the ones of the source"
+	}
+
+	return ""
+}
+
+// aliasServiceTarget returns the DNS name of the Service of the Vault
+func aliasServiceTarget(alias *vaultv1alpha1.AliasService, v *vaultv1alpha1.Vault) string {
+	return fmt.Sprintf("%s.%s.svc.%s", v.Name, v.Namespace, alias.GetClusterDomain())
+}
+
+// pointAliasService creates or updates the alias ExternalName Service to point to the Service of the Vault,
+// a single update switches every new connection over
+func (r *ReconcileVaultMigration) pointAliasService(ctx context.Context, m *vaultv1alpha1.VaultMigration, v *vaultv1alpha1.Vault) error {
+	alias := m.Spec.AliasService
+	namespace := alias.Namespace
+	if namespace == "" {
+		namespace = m.Namespace
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: alias.Name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.nonNamespacedClient, service, func() error {
+		if service.ResourceVersion == "" {
+			service.Spec.Type = corev1.ServiceTypeExternalName
+		}
+		if service.Spec.Type != corev1.ServiceTypeExternalName {
+			return fmt.Errorf("service %s/%s is not an ExternalName Service", namespace, alias.Name)
+		}
+
+		service.Spec.ExternalName = aliasServiceTarget(alias, v)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to point the alias service to vault %s/%s: %v", v.Namespace, v.Name, err)
+	}
+
+	return nil
+}
+
+// checkUnsealKeys checks that the unseal keys of the source can be read, if they are going to be copied
+func (r *ReconcileVaultMigration) checkUnsealKeys(ctx context.Context, sourceVault, targetVault *vaultv1alpha1.Vault) error {
+	sourceNamespace, sourceName, sourceOK := sourceVault.Spec.UnsealConfig.GetKubernetesSecret(sourceVault)
+	targetNamespace, targetName, targetOK := targetVault.Spec.UnsealConfig.GetKubernetesSecret(targetVault)
+	if !sourceOK || !targetOK || (sourceNamespace == targetNamespace && sourceName == targetName) {
+		return nil
+	}
+
+	sourceSecret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: sourceName}, sourceSecret); err != nil {
+		return fmt.Errorf("failed to get the unseal keys of the source vault: %v", err)
+	}
+	if _, ok := sourceSecret.Data["vault-unseal-0"]; !ok {
+		return fmt.Errorf("secret %s/%s holds no unseal keys", sourceNamespace, sourceName)
+	}
+
+	return nil
+}
+
+// copyUnsealKeys copies the unseal keys and the root token of the source to the target,
+// if both are stored in Kubernetes Secrets
+func (r *ReconcileVaultMigration) copyUnsealKeys(ctx context.Context, sourceVault, targetVault *vaultv1alpha1.Vault) error {
+	sourceNamespace, sourceName, sourceOK := sourceVault.Spec.UnsealConfig.GetKubernetesSecret(sourceVault)
+	targetNamespace, targetName, targetOK := targetVault.Spec.UnsealConfig.GetKubernetesSecret(targetVault)
+	if !sourceOK || !targetOK || (sourceNamespace == targetNamespace && sourceName == targetName) {
+		return nil
+	}
+
+	sourceSecret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: sourceName}, sourceSecret); err != nil {
+		return fmt.Errorf("failed to get the unseal keys of the source vault: %v", err)
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: targetNamespace, Name: targetName}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.nonNamespacedClient, secret, func() error {
+		secret.Data = sourceSecret.Data
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy the unseal keys to the target vault: %v", err)
+	}
+
+	return nil
+}
+
+// vaultToken returns the referenced token, or the root token stored with the unseal keys
+func (r *ReconcileVaultMigration) vaultToken(ctx context.Context, v *vaultv1alpha1.Vault, ref *corev1.SecretKeySelector) (string, error) {
+	namespace, name, key := v.Namespace, "", "vault-root"
+	if ref != nil {
+		name, key = ref.Name, ref.Key
+	} else {
+		if v.Spec.UnsealConfig.RevokesRootToken() {
+			return "", fmt.Errorf("the root token is revoked, set tokenSecretRef")
+		}
+
+		var ok bool
+		namespace, name, ok = v.Spec.UnsealConfig.GetKubernetesSecret(v)
+		if !ok {
+			return "", fmt.Errorf("the root token is not stored in a Kubernetes Secret, set tokenSecretRef")
+		}
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return "", err
+	}
+	token, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", namespace, name, key)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// getVault returns the referenced Vault, or nil if it is not found
+func (r *ReconcileVaultMigration) getVault(ctx context.Context, m *vaultv1alpha1.VaultMigration, ref vaultv1alpha1.VaultReference) (*vaultv1alpha1.Vault, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = m.Namespace
+	}
+
+	v := &vaultv1alpha1.Vault{}
+	err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, v)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get vault %s/%s: %v", namespace, ref.Name, err)
+	}
+
+	return v, nil
+}
+
+func (r *ReconcileVaultMigration) fail(ctx context.Context, m *vaultv1alpha1.VaultMigration, message string) error {
+	r.recorder.Event(m, corev1.EventTypeWarning, "VaultMigrationFailed", message)
+	status := m.Status
+	status.Phase = vaultv1alpha1.VaultMigrationFailed
+	status.Message = message
+	return r.updateStatus(ctx, m, status)
+}
+
+func (r *ReconcileVaultMigration) updateStatus(ctx context.Context, m *vaultv1alpha1.VaultMigration, status vaultv1alpha1.VaultMigrationStatus) error {
+	if status.Phase == m.Status.Phase {
+		status.LastTransitionTime = m.Status.LastTransitionTime
+	} else {
+		status.LastTransitionTime = metav1.Now()
+	}
+
+	if m.Status == status {
+		return nil
+	}
+
+	m.Status = status
+	return r.client.Update(ctx, m)
+}
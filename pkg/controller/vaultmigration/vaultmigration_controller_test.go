@@ -0,0 +1,243 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultmigration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/bank-vaults/vault-operator/pkg/controller/internal/testutil"
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testVault(name, config string) *vaultv1alpha1.Vault {
+	return &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:   1,
+			Config: extv1beta1.JSON{Raw: []byte(config)},
+		},
+	}
+}
+
+func newTestReconciler(objects ...client.Object) (*ReconcileVaultMigration, client.Client) {
+	c, scheme := testutil.NewFakeClient(objects...)
+
+	return &ReconcileVaultMigration{client: c, nonNamespacedClient: c, scheme: scheme, recorder: record.NewFakeRecorder(10)}, c
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	raft := `{"storage": {"raft": {"path": "/vault/file"}}}`
+	raftKMS := `{"storage": {"raft": {"path": "/vault/file"}}, "seal": {"awskms": {"kms_key_id": "key"}}}`
+
+	tests := []struct {
+		name    string
+		source  *vaultv1alpha1.Vault
+		target  *vaultv1alpha1.Vault
+		message string
+	}{
+		{
+			name:   "compatible",
+			source: testVault("blue", raft),
+			target: testVault("green", raft),
+		},
+		{
+			name:    "non-raft source",
+			source:  testVault("blue", `{"storage": {"file": {"path": "/vault/file"}}}`),
+			target:  testVault("green", raft),
+			message: "the source and the target vault have to use the raft storage backend",
+		},
+		{
+			name:    "different seals",
+			source:  testVault("blue", raftKMS),
+			target:  testVault("green", raft),
+			message: "the target vault has to use the seal of the source, it can be migrated after the migration",
+		},
+		{
+			name:   "same seals",
+			source: testVault("blue", raftKMS),
+			target: testVault("green", raftKMS),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.message, checkCompatibility(test.source, test.target))
+		})
+	}
+}
+
+func TestPointAliasService(t *testing.T) {
+	blue := testVault("blue", `{}`)
+	green := testVault("green", `{}`)
+	m := &vaultv1alpha1.VaultMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "blue-to-green", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultMigrationSpec{
+			AliasService: &vaultv1alpha1.AliasService{Name: "vault"},
+		},
+	}
+	r, c := newTestReconciler()
+	ctx := context.Background()
+
+	require.NoError(t, r.pointAliasService(ctx, m, blue))
+	service := &corev1.Service{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault"}, service))
+	assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+	assert.Equal(t, "blue.default.svc.cluster.local", service.Spec.ExternalName)
+
+	require.NoError(t, r.pointAliasService(ctx, m, green))
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault"}, service))
+	assert.Equal(t, "green.default.svc.cluster.local", service.Spec.ExternalName)
+
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	require.NoError(t, c.Update(ctx, service))
+	assert.Error(t, r.pointAliasService(ctx, m, blue))
+}
+
+func TestIncompatibleMigrationFails(t *testing.T) {
+	m := &vaultv1alpha1.VaultMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "blue-to-green", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultMigrationSpec{
+			Source: vaultv1alpha1.VaultReference{Name: "blue"},
+			Target: vaultv1alpha1.VaultReference{Name: "green"},
+		},
+	}
+	r, c := newTestReconciler(m,
+		testVault("blue", `{"storage": {"file": {"path": "/vault/file"}}}`),
+		testVault("green", `{"storage": {"raft": {"path": "/vault/file"}}}`),
+	)
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(m)})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultMigrationFailed, m.Status.Phase)
+	assert.Equal(t, "the source and the target vault have to use the raft storage backend", m.Status.Message)
+}
+
+func testSnapshot(t *testing.T) []byte {
+	var buffer bytes.Buffer
+	compressed := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(compressed)
+	for name, content := range map[string]string{"state.bin": strings.Repeat("data", 1024), "SHA256SUMS.sealed": "sealed"} {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, compressed.Close())
+	return buffer.Bytes()
+}
+
+func TestTransferSnapshot(t *testing.T) {
+	snapshot := testSnapshot(t)
+
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sys/storage/raft/snapshot", r.URL.Path)
+		assert.Equal(t, "source-token", r.Header.Get("X-Vault-Token"))
+		_, _ = w.Write(snapshot)
+	}))
+	defer source.Close()
+
+	var restored []byte
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sys/storage/raft/snapshot-force", r.URL.Path)
+		assert.Equal(t, "target-token", r.Header.Get("X-Vault-Token"))
+		var err error
+		restored, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	sourceClient, err := api.NewClient(&api.Config{Address: source.URL})
+	require.NoError(t, err)
+	sourceClient.SetToken("source-token")
+	targetClient, err := api.NewClient(&api.Config{Address: target.URL})
+	require.NoError(t, err)
+	targetClient.SetToken("target-token")
+
+	size, err := transferSnapshot(context.Background(), sourceClient, targetClient)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(snapshot)), size)
+	assert.Equal(t, snapshot, restored)
+
+	// A failed restore is reported as such
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer failing.Close()
+	failingClient, err := api.NewClient(&api.Config{Address: failing.URL})
+	require.NoError(t, err)
+
+	_, err = transferSnapshot(context.Background(), sourceClient, failingClient)
+	assert.ErrorContains(t, err, "failed to restore the snapshot")
+}
+
+func TestSwitchOver(t *testing.T) {
+	ctx := context.Background()
+
+	blue := testVault("blue", `{"storage": {"raft": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`)
+	green := testVault("green", `{"storage": {"raft": {"path": "/vault/file"}}, "listener": {"tcp": {"tls_disable": true}}}`)
+	m := &vaultv1alpha1.VaultMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "blue-to-green", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultMigrationSpec{
+			Source: vaultv1alpha1.VaultReference{Name: "blue"},
+			Target: vaultv1alpha1.VaultReference{Name: "green"},
+		},
+		Status: vaultv1alpha1.VaultMigrationStatus{Phase: vaultv1alpha1.VaultMigrationRestored},
+	}
+	r, c := newTestReconciler(m, blue, green)
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(m)}
+
+	// The source is hibernated, the missing keys are retried instead of failing the migration
+	_, err := r.Reconcile(ctx, request)
+	assert.ErrorContains(t, err, "failed to get the unseal keys of the source vault")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultMigrationRestored, m.Status.Phase)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(blue), blue))
+	assert.True(t, blue.Spec.Hibernate)
+
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "blue-unseal-keys", Namespace: "default"},
+		Data:       map[string][]byte{"vault-root": []byte("root"), "vault-unseal-0": []byte("key")},
+	}
+	require.NoError(t, c.Create(ctx, keys))
+
+	// The keys are copied, the target has no active node yet
+	result, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "green-unseal-keys"}, copied))
+	assert.Equal(t, keys.Data, copied.Data)
+}
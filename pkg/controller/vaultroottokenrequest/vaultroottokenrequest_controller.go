@@ -0,0 +1,333 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultroottokenrequest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	vaultcontroller "github.com/bank-vaults/vault-operator/pkg/controller/vault"
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// The keys of the delivered token Secret
+	tokenKey          = "token"
+	accessorKey       = "accessor"
+	expirationTimeKey = "expirationTime"
+
+	// Requests waiting for an active Vault node are checked again after this period
+	pendingRetryPeriod = 30 * time.Second
+)
+
+var log = logf.Log.WithName("controller_vaultroottokenrequest")
+
+// Add creates a new VaultRootTokenRequest Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	reconciler, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, reconciler)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	nonNamespacedClient, err := client.New(mgr.GetConfig(), client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileVaultRootTokenRequest{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		scheme:              mgr.GetScheme(),
+		recorder:            mgr.GetEventRecorderFor("vaultroottokenrequest-controller"),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("vaultroottokenrequest-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource VaultRootTokenRequest
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.VaultRootTokenRequest{}, &handler.TypedEnqueueRequestForObject[*vaultv1alpha1.VaultRootTokenRequest]{}))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileVaultRootTokenRequest{}
+
+// ReconcileVaultRootTokenRequest reconciles a VaultRootTokenRequest object
+type ReconcileVaultRootTokenRequest struct {
+	client client.Client
+
+	// nonNamespacedClient reads the unseal keys, which may be stored in another namespace
+	nonNamespacedClient client.Client
+
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile generates a root token with the stored unseal keys, and delivers a short-lived token derived from it
+// to a Secret, which is deleted when the token expires.
+func (r *ReconcileVaultRootTokenRequest) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling VaultRootTokenRequest")
+
+	req := &vaultv1alpha1.VaultRootTokenRequest{}
+	err := r.client.Get(ctx, request.NamespacedName, req)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch req.Status.Phase {
+	case vaultv1alpha1.VaultRootTokenRequestReady:
+		return r.expireToken(ctx, req)
+	case vaultv1alpha1.VaultRootTokenRequestExpired, vaultv1alpha1.VaultRootTokenRequestFailed:
+		return reconcile.Result{}, nil
+	}
+
+	v := &vaultv1alpha1.Vault{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Spec.VaultName}, v)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, r.fail(ctx, req, fmt.Sprintf("vault %q not found", req.Spec.VaultName))
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	keys, err := r.unsealKeys(ctx, v)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, req, err.Error())
+	}
+
+	vaultClient, err := vaultcontroller.ActiveVaultClient(ctx, r.nonNamespacedClient, v)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if vaultClient == nil {
+		if err := r.updateStatus(ctx, req, vaultv1alpha1.VaultRootTokenRequestStatus{
+			Phase:   vaultv1alpha1.VaultRootTokenRequestPending,
+			Message: "waiting for an active Vault node",
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	rootToken, err := generateRootToken(vaultClient, keys)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, req, err.Error())
+	}
+
+	// The generated root token never expires, so only a token derived from it with a TTL is handed out
+	vaultClient.SetToken(rootToken)
+	ttl := req.Spec.GetTTL()
+	token, err := vaultClient.Auth().Token().CreateOrphan(&api.TokenCreateRequest{
+		Policies:       []string{"root"},
+		TTL:            ttl.String(),
+		ExplicitMaxTTL: ttl.String(),
+		DisplayName:    "root-token-request-" + req.Name,
+	})
+	if revokeErr := vaultClient.Auth().Token().RevokeSelf(""); revokeErr != nil {
+		reqLogger.Error(revokeErr, "failed to revoke the generated root token")
+	}
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, req, fmt.Sprintf("failed to create token: %v", err))
+	}
+
+	expirationTime := metav1.NewTime(time.Now().Add(ttl))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName(req),
+			Namespace: req.Namespace,
+			Labels:    map[string]string{"vault.banzaicloud.com/root-token-request": req.Name},
+		},
+		Data: map[string][]byte{
+			tokenKey:          []byte(token.Auth.ClientToken),
+			accessorKey:       []byte(token.Auth.Accessor),
+			expirationTimeKey: []byte(expirationTime.UTC().Format(time.RFC3339)),
+		},
+	}
+	if err := controllerutil.SetControllerReference(req, secret, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.client.Create(ctx, secret); err != nil {
+		// The token is useless if it can't be delivered
+		vaultClient.SetToken(token.Auth.ClientToken)
+		_ = vaultClient.Auth().Token().RevokeSelf("")
+		return reconcile.Result{}, r.fail(ctx, req, fmt.Sprintf("failed to create token secret: %v", err))
+	}
+
+	r.recorder.Eventf(req, corev1.EventTypeNormal, "RootTokenDelivered", "root token with accessor %s delivered to secret %s, expires at %s",
+		token.Auth.Accessor, secret.Name, expirationTime.UTC().Format(time.RFC3339))
+
+	err = r.updateStatus(ctx, req, vaultv1alpha1.VaultRootTokenRequestStatus{
+		Phase:          vaultv1alpha1.VaultRootTokenRequestReady,
+		SecretName:     secret.Name,
+		Accessor:       token.Auth.Accessor,
+		ExpirationTime: expirationTime,
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: ttl}, nil
+}
+
+func secretName(req *vaultv1alpha1.VaultRootTokenRequest) string {
+	if req.Spec.SecretName != "" {
+		return req.Spec.SecretName
+	}
+	return req.Name
+}
+
+// expireToken deletes the token Secret once the token has expired
+func (r *ReconcileVaultRootTokenRequest) expireToken(ctx context.Context, req *vaultv1alpha1.VaultRootTokenRequest) (reconcile.Result, error) {
+	if remaining := time.Until(req.Status.ExpirationTime.Time); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Status.SecretName}}
+	if err := r.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to delete token secret: %v", err)
+	}
+
+	r.recorder.Eventf(req, corev1.EventTypeNormal, "RootTokenExpired", "root token expired, secret %s deleted", secret.Name)
+
+	status := req.Status
+	status.Phase = vaultv1alpha1.VaultRootTokenRequestExpired
+	return reconcile.Result{}, r.updateStatus(ctx, req, status)
+}
+
+// unsealKeys returns the unseal keys of the Vault, or its recovery keys with auto-unseal, which have to be stored
+// in a Kubernetes Secret
+func (r *ReconcileVaultRootTokenRequest) unsealKeys(ctx context.Context, v *vaultv1alpha1.Vault) ([]string, error) {
+	namespace, name, ok := v.Spec.UnsealConfig.GetKubernetesSecret(v)
+	if !ok || v.Spec.UnsealConfig.HSM != nil {
+		return nil, fmt.Errorf("generating a root token is only supported if the unseal keys are stored in a Kubernetes Secret")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get unseal keys secret: %v", err)
+	}
+
+	keys := vaultcontroller.StoredSealKeys(v, secret.Data)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("secret %s/%s holds no unseal or recovery keys", namespace, name)
+	}
+
+	return keys, nil
+}
+
+// generateRootToken runs the sys/generate-root flow with the unseal keys
+func generateRootToken(vaultClient *api.Client, keys []string) (string, error) {
+	// The flow is run in a single reconcile, so one in progress has been started by someone else
+	if status, err := vaultClient.Sys().GenerateRootStatus(); err == nil && status.Started {
+		if err := vaultClient.Sys().GenerateRootCancel(); err != nil {
+			return "", fmt.Errorf("failed to cancel root token generation in progress: %v", err)
+		}
+	}
+
+	init, err := vaultClient.Sys().GenerateRootInit("", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to start root token generation: %v", err)
+	}
+	if init.OTP == "" {
+		_ = vaultClient.Sys().GenerateRootCancel()
+		return "", fmt.Errorf("vault hasn't returned a one-time password, Vault 1.10 or newer is required")
+	}
+
+	for _, key := range keys {
+		status, err := vaultClient.Sys().GenerateRootUpdate(key, init.Nonce)
+		if err != nil {
+			_ = vaultClient.Sys().GenerateRootCancel()
+			return "", fmt.Errorf("failed to submit unseal key: %v", err)
+		}
+		if status.Complete {
+			encodedToken := status.EncodedToken
+			if encodedToken == "" {
+				encodedToken = status.EncodedRootToken
+			}
+			return decodeRootToken(encodedToken, init.OTP)
+		}
+	}
+
+	_ = vaultClient.Sys().GenerateRootCancel()
+	return "", fmt.Errorf("the stored unseal keys couldn't complete the root token generation")
+}
+
+// decodeRootToken decodes the encoded root token with the one-time password
+func decodeRootToken(encodedToken, otp string) (string, error) {
+	tokenBytes, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(encodedToken, "="))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode root token: %v", err)
+	}
+	if len(tokenBytes) != len(otp) {
+		return "", fmt.Errorf("the length of the encoded root token doesn't match the one-time password")
+	}
+
+	for i := range tokenBytes {
+		tokenBytes[i] ^= otp[i]
+	}
+
+	return string(tokenBytes), nil
+}
+
+func (r *ReconcileVaultRootTokenRequest) fail(ctx context.Context, req *vaultv1alpha1.VaultRootTokenRequest, message string) error {
+	r.recorder.Event(req, corev1.EventTypeWarning, "RootTokenRequestFailed", message)
+	return r.updateStatus(ctx, req, vaultv1alpha1.VaultRootTokenRequestStatus{
+		Phase:   vaultv1alpha1.VaultRootTokenRequestFailed,
+		Message: message,
+	})
+}
+
+func (r *ReconcileVaultRootTokenRequest) updateStatus(ctx context.Context, req *vaultv1alpha1.VaultRootTokenRequest, status vaultv1alpha1.VaultRootTokenRequestStatus) error {
+	if req.Status == status {
+		return nil
+	}
+
+	req.Status = status
+	return r.client.Update(ctx, req)
+}
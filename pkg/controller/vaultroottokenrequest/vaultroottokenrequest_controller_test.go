@@ -0,0 +1,119 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultroottokenrequest
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/bank-vaults/vault-operator/pkg/controller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestReconciler(objects ...client.Object) (*ReconcileVaultRootTokenRequest, client.Client) {
+	c, scheme := testutil.NewFakeClient(objects...)
+
+	return &ReconcileVaultRootTokenRequest{client: c, nonNamespacedClient: c, scheme: scheme, recorder: record.NewFakeRecorder(10)}, c
+}
+
+func TestDecodeRootToken(t *testing.T) {
+	token := "hvs.AAAAAAAAAAAAAAAAAAAAAAAA"
+	otp := "T2hlZ2l1ZWtlaWNob29wNmFoa28x"
+
+	encoded := make([]byte, len(token))
+	for i := range encoded {
+		encoded[i] = token[i] ^ otp[i]
+	}
+
+	decoded, err := decodeRootToken(base64.RawStdEncoding.EncodeToString(encoded), otp)
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+
+	_, err = decodeRootToken(base64.RawStdEncoding.EncodeToString(encoded), otp[1:])
+	assert.Error(t, err)
+}
+
+func TestUnsealKeys(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data: map[string][]byte{
+			"vault-root":       []byte("root"),
+			"vault-unseal-0":   []byte("unseal-0"),
+			"vault-unseal-1":   []byte("unseal-1"),
+			"vault-recovery-0": []byte("recovery-0"),
+		},
+	}
+	reconciler, _ := newTestReconciler(secret)
+
+	v := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Config:       extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}}`)},
+			UnsealConfig: vaultv1alpha1.UnsealConfig{Kubernetes: vaultv1alpha1.KubernetesUnsealConfig{SecretNamespace: "default"}},
+		},
+	}
+
+	keys, err := reconciler.unsealKeys(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unseal-0", "unseal-1"}, keys)
+
+	// With auto-unseal the root token is generated with the recovery keys
+	v.Spec.Config = extv1beta1.JSON{Raw: []byte(`{"storage": {"raft": {"path": "/vault/file"}}, "seal": {"awskms": {"kms_key_id": "key"}}}`)}
+	keys, err = reconciler.unsealKeys(ctx, v)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"recovery-0"}, keys)
+}
+
+func TestExpireToken(t *testing.T) {
+	ctx := context.Background()
+
+	req := &vaultv1alpha1.VaultRootTokenRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultRootTokenRequestSpec{VaultName: "vault"},
+		Status: vaultv1alpha1.VaultRootTokenRequestStatus{
+			Phase:          vaultv1alpha1.VaultRootTokenRequestReady,
+			SecretName:     "break-glass",
+			ExpirationTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "default"},
+		Data:       map[string][]byte{tokenKey: []byte("token")},
+	}
+
+	reconciler, c := newTestReconciler(req, secret)
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(req)})
+	require.NoError(t, err)
+
+	err = c.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(req), req))
+	assert.Equal(t, vaultv1alpha1.VaultRootTokenRequestExpired, req.Status.Phase)
+}
@@ -0,0 +1,650 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultstoragemigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// The paths of the migration Job
+	configMountPath      = "/vault/migration"
+	sourceMountPath      = "/vault/migration-source"
+	destinationMountPath = "/vault/migration-destination"
+
+	// Migrations waiting for Pods or Jobs are checked again after this period
+	pendingRetryPeriod = 10 * time.Second
+)
+
+// migrationScript migrates the data (the source is written too, vault operator migrate locks it), then migrates it again from the destination to memory, to count the keys
+// the destination holds. The key counts are reported in the termination message.
+const migrationScript = `vault operator migrate -config ` + configMountPath + `/migrate.json > /tmp/migrate.log 2>&1
+status=$?
+cat /tmp/migrate.log
+[ $status -eq 0 ] || exit $status
+migrated=$(grep -c "copied key" /tmp/migrate.log)
+
+vault operator migrate -config ` + configMountPath + `/verify.json > /tmp/verify.log 2>&1 || { cat /tmp/verify.log; exit 1; }
+stored=$(grep -c "copied key" /tmp/verify.log)
+
+# Vault runs as the vault user, the files of the destination are written by the migration
+chown -R vault:vault ` + destinationMountPath + ` 2>/dev/null || true
+
+echo "$migrated $stored" > /dev/termination-log
+echo "migrated $migrated keys, the destination holds $stored keys"
+[ "$migrated" -eq "$stored" ]
+`
+
+var log = logf.Log.WithName("controller_vaultstoragemigration")
+
+// Add creates a new VaultStorageMigration Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	reconciler, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, reconciler)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	nonNamespacedClient, err := client.New(mgr.GetConfig(), client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileVaultStorageMigration{
+		client:              mgr.GetClient(),
+		nonNamespacedClient: nonNamespacedClient,
+		scheme:              mgr.GetScheme(),
+		recorder:            mgr.GetEventRecorderFor("vaultstoragemigration-controller"),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("vaultstoragemigration-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource VaultStorageMigration
+	err = c.Watch(source.Kind(mgr.GetCache(), &vaultv1alpha1.VaultStorageMigration{}, &handler.TypedEnqueueRequestForObject[*vaultv1alpha1.VaultStorageMigration]{}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the migration Jobs
+	err = c.Watch(source.Kind(mgr.GetCache(), &batchv1.Job{},
+		handler.TypedEnqueueRequestForOwner[*batchv1.Job](mgr.GetScheme(), mgr.GetRESTMapper(), &vaultv1alpha1.VaultStorageMigration{}, handler.OnlyControllerOwner())))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileVaultStorageMigration{}
+
+// ReconcileVaultStorageMigration reconciles a VaultStorageMigration object
+type ReconcileVaultStorageMigration struct {
+	client client.Client
+
+	// nonNamespacedClient copies the unseal keys, which may be stored in another namespace
+	nonNamespacedClient client.Client
+
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile migrates the data of a Vault to the raft storage of another one: it hibernates the source,
+// runs vault operator migrate in a Job, and wakes up the destination.
+func (r *ReconcileVaultStorageMigration) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling VaultStorageMigration")
+
+	m := &vaultv1alpha1.VaultStorageMigration{}
+	err := r.client.Get(ctx, request.NamespacedName, m)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch m.Status.Phase {
+	case vaultv1alpha1.VaultStorageMigrationCompleted, vaultv1alpha1.VaultStorageMigrationFailed:
+		return reconcile.Result{}, nil
+	}
+
+	source := &vaultv1alpha1.Vault{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: m.Spec.SourceVaultName}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.fail(ctx, m, fmt.Sprintf("source vault %q not found", m.Spec.SourceVaultName))
+		}
+		return reconcile.Result{}, err
+	}
+
+	destination := &vaultv1alpha1.Vault{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: m.Spec.DestinationVaultName}, destination); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.fail(ctx, m, fmt.Sprintf("destination vault %q not found", m.Spec.DestinationVaultName))
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch m.Status.Phase {
+	case vaultv1alpha1.VaultStorageMigrationScalingDown:
+		return r.startMigration(ctx, m, source, destination)
+	case vaultv1alpha1.VaultStorageMigrationMigrating:
+		return r.checkMigration(ctx, m, source, destination)
+	case vaultv1alpha1.VaultStorageMigrationStartingDestination:
+		return r.checkDestination(ctx, m, destination)
+	}
+
+	// Nothing is touched before the migration is known to be possible
+	if _, err := migrationJob(m, source, destination); err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, err.Error())
+	}
+	if message := checkCompatibility(source, destination); message != "" {
+		return reconcile.Result{}, r.fail(ctx, m, message)
+	}
+	if !destination.Spec.Hibernate {
+		return reconcile.Result{}, r.fail(ctx, m, fmt.Sprintf("destination vault %q has to be hibernated until the migration, create it with hibernate: true", destination.Name))
+	}
+	if source.Spec.Paused || destination.Spec.Paused {
+		return reconcile.Result{}, r.fail(ctx, m, "the source and the destination vault must not be paused")
+	}
+
+	if err := r.setHibernate(ctx, client.ObjectKeyFromObject(source), true); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to hibernate source vault: %v", err)
+	}
+
+	r.recorder.Eventf(m, corev1.EventTypeNormal, "SourceHibernated", "vault %s is hibernated for the migration", source.Name)
+
+	return reconcile.Result{RequeueAfter: pendingRetryPeriod}, r.updateStatus(ctx, m, vaultv1alpha1.VaultStorageMigrationStatus{
+		Phase:   vaultv1alpha1.VaultStorageMigrationScalingDown,
+		Message: fmt.Sprintf("waiting for the pods of vault %s to stop", source.Name),
+	})
+}
+
+// startMigration starts the migration Job once the Pods of the source Vault have stopped
+func (r *ReconcileVaultStorageMigration) startMigration(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, source, destination *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(source), statefulSet)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to get source StatefulSet: %v", err)
+	}
+	if err == nil && (statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas != 0 || statefulSet.Status.Replicas != 0) {
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	pods := &corev1.PodList{}
+	err = r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     source.Namespace,
+		LabelSelector: labels.SelectorFromSet(source.LabelsForVault()),
+	})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list source pods: %v", err)
+	}
+	if len(pods.Items) > 0 {
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	job, err := migrationJob(m, source, destination)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, err.Error())
+	}
+
+	config, err := migrationConfig(m, source, destination)
+	if err != nil {
+		return reconcile.Result{}, r.fail(ctx, m, err.Error())
+	}
+	if err := controllerutil.SetControllerReference(m, config, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.client.Create(ctx, config); err != nil && !apierrors.IsAlreadyExists(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to create migration config: %v", err)
+	}
+
+	// The destination has never run, so the claims of its StatefulSet may be missing, it adopts them later
+	if err := r.ensureClaims(ctx, destination); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := controllerutil.SetControllerReference(m, job, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to create migration job: %v", err)
+	}
+
+	r.recorder.Eventf(m, corev1.EventTypeNormal, "MigrationStarted", "migrating the %s storage of vault %s to vault %s in job %s",
+		source.Spec.GetStorageType(), source.Name, destination.Name, job.Name)
+
+	return reconcile.Result{RequeueAfter: pendingRetryPeriod}, r.updateStatus(ctx, m, vaultv1alpha1.VaultStorageMigrationStatus{
+		Phase:   vaultv1alpha1.VaultStorageMigrationMigrating,
+		Job:     job.Name,
+		Message: fmt.Sprintf("job %s is migrating the data", job.Name),
+	})
+}
+
+// checkMigration wakes up the destination Vault once the migration Job has succeeded, or the source once it has failed
+func (r *ReconcileVaultStorageMigration) checkMigration(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, source, destination *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: m.Status.Job}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.rollBack(ctx, m, source, fmt.Sprintf("migration job %s not found", m.Status.Job))
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get migration job: %v", err)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return reconcile.Result{}, r.rollBack(ctx, m, source, fmt.Sprintf("migration job %s has failed: %s, "+
+				"the raft data of vault %s has to be deleted before retrying", job.Name, condition.Message, destination.Name))
+		}
+	}
+	if job.Status.Succeeded == 0 {
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	migratedKeys, destinationKeys, err := r.keyCounts(ctx, job)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.copyUnsealKeys(ctx, source, destination); err != nil {
+		return reconcile.Result{}, r.rollBack(ctx, m, source, err.Error())
+	}
+
+	if err := r.setHibernate(ctx, client.ObjectKeyFromObject(destination), false); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to wake up destination vault: %v", err)
+	}
+
+	r.recorder.Eventf(m, corev1.EventTypeNormal, "DataMigrated", "migrated %d keys, vault %s holds %d keys, waking it up",
+		migratedKeys, destination.Name, destinationKeys)
+
+	return reconcile.Result{RequeueAfter: pendingRetryPeriod}, r.updateStatus(ctx, m, vaultv1alpha1.VaultStorageMigrationStatus{
+		Phase:           vaultv1alpha1.VaultStorageMigrationStartingDestination,
+		Job:             job.Name,
+		MigratedKeys:    migratedKeys,
+		DestinationKeys: destinationKeys,
+		Message:         fmt.Sprintf("waiting for an active node of vault %s", destination.Name),
+	})
+}
+
+// checkDestination completes the migration once the destination Vault has an active node
+func (r *ReconcileVaultStorageMigration) checkDestination(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, destination *vaultv1alpha1.Vault) (reconcile.Result, error) {
+	if destination.Status.Leader == "" {
+		return reconcile.Result{RequeueAfter: pendingRetryPeriod}, nil
+	}
+
+	r.recorder.Eventf(m, corev1.EventTypeNormal, "MigrationCompleted", "vault %s is active with the migrated data", destination.Name)
+
+	status := m.Status
+	status.Phase = vaultv1alpha1.VaultStorageMigrationCompleted
+	status.Message = fmt.Sprintf("vault %s is active on %s, vault %s is left hibernated", destination.Name, destination.Status.Leader, m.Spec.SourceVaultName)
+	return reconcile.Result{}, r.updateStatus(ctx, m, status)
+}
+
+// keyCounts reads the key counts from the termination message of the migration Pod
+func (r *ReconcileVaultStorageMigration) keyCounts(ctx context.Context, job *batchv1.Job) (int, int, error) {
+	pods := &corev1.PodList{}
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"job-name": job.Name}),
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list migration pods: %v", err)
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated == nil || status.State.Terminated.ExitCode != 0 {
+				continue
+			}
+
+			var migratedKeys, destinationKeys int
+			if _, err := fmt.Sscanf(status.State.Terminated.Message, "%d %d", &migratedKeys, &destinationKeys); err == nil {
+				return migratedKeys, destinationKeys, nil
+			}
+		}
+	}
+
+	// The Job verifies the counts itself, so they are only missing from the status
+	return 0, 0, nil
+}
+
+// checkCompatibility returns why the destination couldn't be unsealed after the migration,
+// or an empty string if it could
+func checkCompatibility(source, destination *vaultv1alpha1.Vault) string {
+	// The migrated keyring is encrypted by the root key of the source, which is protected by its seal
+	if !reflect.DeepEqual(source.Spec.GetVaultConfig()["seal"], destination.Spec.GetVaultConfig()["seal"]) {
+		return "the destination vault has to use the seal of the source, it can be migrated after the migration"
+	}
+
+	_, _, sourceKubernetes := source.Spec.UnsealConfig.GetKubernetesSecret(source)
+	_, _, destinationKubernetes := destination.Spec.UnsealConfig.GetKubernetesSecret(destination)
+	if (!sourceKubernetes || !destinationKubernetes) && !reflect.DeepEqual(source.Spec.UnsealConfig, destination.Spec.UnsealConfig) {
+		return "the unseal keys of the source can only be copied between Kubernetes Secrets, store the keys of the destination with the ones of the source"
+	}
+
+	return ""
+}
+
+// copyUnsealKeys copies the unseal keys of the source to the destination if both are stored in Kubernetes Secrets,
+// otherwise checkCompatibility has made sure that they share the same keystore
+func (r *ReconcileVaultStorageMigration) copyUnsealKeys(ctx context.Context, source, destination *vaultv1alpha1.Vault) error {
+	sourceNamespace, sourceName, sourceOK := source.Spec.UnsealConfig.GetKubernetesSecret(source)
+	destinationNamespace, destinationName, destinationOK := destination.Spec.UnsealConfig.GetKubernetesSecret(destination)
+	if !sourceOK || !destinationOK || (sourceNamespace == destinationNamespace && sourceName == destinationName) {
+		return nil
+	}
+
+	sourceSecret := &corev1.Secret{}
+	if err := r.nonNamespacedClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: sourceName}, sourceSecret); err != nil {
+		return fmt.Errorf("failed to get the unseal keys of the source vault: %v", err)
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: destinationNamespace, Name: destinationName}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.nonNamespacedClient, secret, func() error {
+		secret.Data = sourceSecret.Data
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy the unseal keys to the destination vault: %v", err)
+	}
+
+	return nil
+}
+
+// ensureClaims creates the claims of the first Pod of the Vault from its templates
+func (r *ReconcileVaultStorageMigration) ensureClaims(ctx context.Context, v *vaultv1alpha1.Vault) error {
+	for _, template := range v.Spec.GetVolumeClaimTemplates() {
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        claimName(v, template.Name),
+				Namespace:   v.Namespace,
+				Labels:      v.LabelsForVault(),
+				Annotations: template.Annotations,
+			},
+			Spec: template.Spec,
+		}
+		for key, value := range template.Labels {
+			claim.Labels[key] = value
+		}
+
+		if err := r.client.Create(ctx, claim); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create claim %s: %v", claim.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// claimName returns the name of the claim the StatefulSet creates for the first Vault Pod from a template
+func claimName(v *vaultv1alpha1.Vault, template string) string {
+	return fmt.Sprintf("%s-%s-0", template, v.Name)
+}
+
+// dataVolume returns the volume of the first Vault Pod holding the path, and the path inside of the volume
+func dataVolume(v *vaultv1alpha1.Vault, path string) (corev1.VolumeSource, string, error) {
+	var mount *corev1.VolumeMount
+	for i, m := range v.Spec.VolumeMounts {
+		if (path == m.MountPath || strings.HasPrefix(path, strings.TrimSuffix(m.MountPath, "/")+"/")) &&
+			(mount == nil || len(m.MountPath) > len(mount.MountPath)) {
+			mount = &v.Spec.VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		return corev1.VolumeSource{}, "", fmt.Errorf("the storage path %s of vault %s is not on a volume", path, v.Name)
+	}
+
+	subPath := filepath.Join(mount.SubPath, strings.TrimPrefix(path, mount.MountPath))
+
+	for _, volume := range v.Spec.Volumes {
+		if volume.Name != mount.Name {
+			continue
+		}
+		if volume.EmptyDir != nil {
+			return corev1.VolumeSource{}, "", fmt.Errorf("the storage path %s of vault %s is on an emptyDir, which doesn't survive hibernation", path, v.Name)
+		}
+		return volume.VolumeSource, subPath, nil
+	}
+
+	for _, template := range v.Spec.GetVolumeClaimTemplates() {
+		if template.Name == mount.Name {
+			return corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName(v, template.Name)},
+			}, subPath, nil
+		}
+	}
+
+	return corev1.VolumeSource{}, "", fmt.Errorf("volume %s of vault %s not found", mount.Name, v.Name)
+}
+
+// withPod replaces the Pod name templates in a value of the Vault config with the name of the first Vault Pod
+func withPod(v *vaultv1alpha1.Vault, value string) string {
+	return strings.ReplaceAll(value, "${.Env.POD_NAME}", v.Name+"-0")
+}
+
+// migrationConfig returns the Secret holding the configs of the migration, and of the verification
+func migrationConfig(m *vaultv1alpha1.VaultStorageMigration, source, destination *vaultv1alpha1.Vault) (*corev1.Secret, error) {
+	sourceType := source.Spec.GetStorageType()
+	sourceStorage := source.Spec.GetStorage()
+	if sourceType == "file" {
+		_, subPath, err := dataVolume(source, fmt.Sprint(sourceStorage["path"]))
+		if err != nil {
+			return nil, err
+		}
+		sourceStorage["path"] = filepath.Join(sourceMountPath, subPath)
+	}
+
+	destinationStorage := destination.Spec.GetStorage()
+	_, subPath, err := dataVolume(destination, fmt.Sprint(destinationStorage["path"]))
+	if err != nil {
+		return nil, err
+	}
+	raft := map[string]interface{}{"path": filepath.Join(destinationMountPath, subPath)}
+	if nodeID, ok := destinationStorage["node_id"].(string); ok && nodeID != "" {
+		raft["node_id"] = withPod(destination, nodeID)
+	}
+
+	clusterAddr := fmt.Sprintf("https://%s-0:8201", destination.Name)
+	if addr, ok := destination.Spec.GetVaultConfig()["cluster_addr"].(string); ok && addr != "" {
+		clusterAddr = withPod(destination, addr)
+	}
+
+	migrate, err := json.Marshal(map[string]interface{}{
+		"storage_source":      map[string]interface{}{sourceType: sourceStorage},
+		"storage_destination": map[string]interface{}{"raft": raft},
+		"cluster_addr":        clusterAddr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	verify, err := json.Marshal(map[string]interface{}{
+		"storage_source":      map[string]interface{}{"raft": raft},
+		"storage_destination": map[string]interface{}{"inmem": map[string]interface{}{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name + "-migrate-config",
+			Namespace: m.Namespace,
+		},
+		Data: map[string][]byte{
+			"migrate.json": migrate,
+			"verify.json":  verify,
+		},
+	}, nil
+}
+
+// migrationJob returns the Job running vault operator migrate with the data volumes of both Vaults
+func migrationJob(m *vaultv1alpha1.VaultStorageMigration, source, destination *vaultv1alpha1.Vault) (*batchv1.Job, error) {
+	if source.Spec.IsRaftStorage() || source.Spec.GetStorageType() == "" {
+		return nil, fmt.Errorf("source vault %q has to use a storage backend other than raft", source.Name)
+	}
+	if destination.Spec.GetStorageType() != "raft" {
+		return nil, fmt.Errorf("destination vault %q has to use the raft storage backend", destination.Name)
+	}
+
+	volumes := []corev1.Volume{{
+		Name: "migration-config",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: m.Name + "-migrate-config"},
+		},
+	}}
+	volumeMounts := []corev1.VolumeMount{{Name: "migration-config", MountPath: configMountPath}}
+
+	if source.Spec.GetStorageType() == "file" {
+		volume, _, err := dataVolume(source, fmt.Sprint(source.Spec.GetStorage()["path"]))
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, corev1.Volume{Name: "source", VolumeSource: volume})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "source", MountPath: sourceMountPath})
+	}
+
+	volume, _, err := dataVolume(destination, fmt.Sprint(destination.Spec.GetStorage()["path"]))
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, corev1.Volume{Name: "destination", VolumeSource: volume})
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "destination", MountPath: destinationMountPath})
+
+	image := m.Spec.Image
+	if image == "" {
+		image = source.Spec.GetVaultImage()
+	}
+
+	securityContext := &source.Spec.SecurityContext
+	if securityContext.Size() == 0 {
+		securityContext = &corev1.PodSecurityContext{FSGroup: ptr.To(int64(1000))}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name + "-migrate",
+			Namespace: m.Namespace,
+			Labels:    map[string]string{"vault.banzaicloud.com/storage-migration": m.Name},
+		},
+		Spec: batchv1.JobSpec{
+			// The destination has to be cleaned up before retrying
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"vault.banzaicloud.com/storage-migration": m.Name},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: source.Spec.GetServiceAccount(),
+					SecurityContext:    securityContext,
+					NodeSelector:       source.Spec.NodeSelector,
+					Tolerations:        source.Spec.Tolerations,
+					Volumes:            volumes,
+					Containers: []corev1.Container{{
+						Name:            "migrate",
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"sh", "-c", migrationScript},
+						VolumeMounts:    volumeMounts,
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+// setHibernate hibernates or wakes up a Vault
+func (r *ReconcileVaultStorageMigration) setHibernate(ctx context.Context, key client.ObjectKey, hibernate bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		v := &vaultv1alpha1.Vault{}
+		if err := r.client.Get(ctx, key, v); err != nil {
+			return err
+		}
+		if v.Spec.Hibernate == hibernate {
+			return nil
+		}
+
+		v.Spec.Hibernate = hibernate
+		return r.client.Update(ctx, v)
+	})
+}
+
+// rollBack wakes up the source Vault and fails the migration
+func (r *ReconcileVaultStorageMigration) rollBack(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, source *vaultv1alpha1.Vault, message string) error {
+	if err := r.setHibernate(ctx, client.ObjectKeyFromObject(source), false); err != nil {
+		return fmt.Errorf("failed to wake up source vault: %v", err)
+	}
+
+	return r.fail(ctx, m, fmt.Sprintf("%s, vault %s is woken up", message, source.Name))
+}
+
+func (r *ReconcileVaultStorageMigration) fail(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, message string) error {
+	r.recorder.Event(m, corev1.EventTypeWarning, "StorageMigrationFailed", message)
+	status := m.Status
+	status.Phase = vaultv1alpha1.VaultStorageMigrationFailed
+	status.Message = message
+	return r.updateStatus(ctx, m, status)
+}
+
+func (r *ReconcileVaultStorageMigration) updateStatus(ctx context.Context, m *vaultv1alpha1.VaultStorageMigration, status vaultv1alpha1.VaultStorageMigrationStatus) error {
+	if status.Phase == m.Status.Phase {
+		status.LastTransitionTime = m.Status.LastTransitionTime
+	} else {
+		status.LastTransitionTime = metav1.Now()
+	}
+
+	if m.Status == status {
+		return nil
+	}
+
+	m.Status = status
+	return r.client.Update(ctx, m)
+}
@@ -0,0 +1,213 @@
+// Copyright © 2026 Bank-Vaults Maintainers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultstoragemigration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	vaultv1alpha1 "github.com/bank-vaults/vault-operator/pkg/apis/vault/v1alpha1"
+	"github.com/bank-vaults/vault-operator/pkg/controller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testVaults(destinationHibernated bool) (*vaultv1alpha1.Vault, *vaultv1alpha1.Vault) {
+	source := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size: 1,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"file": {"path": "/vault/file"}}}`),
+			},
+			Volumes: []corev1.Volume{{
+				Name: "vault-file",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "vault-file"},
+				},
+			}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "vault-file", MountPath: "/vault/file"}},
+		},
+	}
+
+	destination := &vaultv1alpha1.Vault{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-raft", Namespace: "default"},
+		Spec: vaultv1alpha1.VaultSpec{
+			Size:      3,
+			Hibernate: destinationHibernated,
+			Config: extv1beta1.JSON{
+				Raw: []byte(`{"storage": {"raft": {"path": "/vault/file/raft", "node_id": "${.Env.POD_NAME}"}}, "cluster_addr": "https://${.Env.POD_NAME}:8201"}`),
+			},
+			VolumeClaimTemplates: []vaultv1alpha1.EmbeddedPersistentVolumeClaim{{
+				EmbeddedObjectMetadata: vaultv1alpha1.EmbeddedObjectMetadata{Name: "vault-raft"},
+			}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "vault-raft", MountPath: "/vault/file"}},
+		},
+	}
+
+	return source, destination
+}
+
+func newTestReconciler(objects ...client.Object) (*ReconcileVaultStorageMigration, client.Client) {
+	c, scheme := testutil.NewFakeClient(objects...)
+
+	return &ReconcileVaultStorageMigration{client: c, nonNamespacedClient: c, scheme: scheme, recorder: record.NewFakeRecorder(10)}, c
+}
+
+func TestStorageMigration(t *testing.T) {
+	ctx := context.Background()
+
+	source, destination := testVaults(true)
+	unsealKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-unseal-keys", Namespace: "default"},
+		Data:       map[string][]byte{"vault-root": []byte("root"), "vault-unseal-0": []byte("key")},
+	}
+	m := &vaultv1alpha1.VaultStorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "to-raft", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultStorageMigrationSpec{SourceVaultName: "vault", DestinationVaultName: "vault-raft"},
+	}
+	r, c := newTestReconciler(source, destination, unsealKeys, m)
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(m)}
+
+	// The source is hibernated first
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationScalingDown, m.Status.Phase)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.True(t, source.Spec.Hibernate)
+
+	// The source has no Pods, the migration Job is started
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationMigrating, m.Status.Phase)
+	assert.Equal(t, "to-raft-migrate", m.Status.Job)
+
+	config := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "to-raft-migrate-config"}, config))
+	var migrate map[string]interface{}
+	require.NoError(t, json.Unmarshal(config.Data["migrate.json"], &migrate))
+	assert.Equal(t, map[string]interface{}{"file": map[string]interface{}{"path": sourceMountPath}}, migrate["storage_source"])
+	assert.Equal(t, map[string]interface{}{"raft": map[string]interface{}{"path": destinationMountPath + "/raft", "node_id": "vault-raft-0"}}, migrate["storage_destination"])
+	assert.Equal(t, "https://vault-raft-0:8201", migrate["cluster_addr"])
+
+	claim := &corev1.PersistentVolumeClaim{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-raft-vault-raft-0"}, claim))
+
+	job := &batchv1.Job{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: m.Status.Job}, job))
+	volumes := job.Spec.Template.Spec.Volumes
+	require.Len(t, volumes, 3)
+	assert.Equal(t, "vault-file", volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "vault-raft-vault-raft-0", volumes[2].PersistentVolumeClaim.ClaimName)
+
+	// The Job has succeeded, the destination is woken up with the keys of the source
+	job.Status.Succeeded = 1
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, c.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "to-raft-migrate-abcde", Namespace: "default", Labels: map[string]string{"job-name": job.Name}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "migrate",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: "42 42\n"}},
+		}}},
+	}))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationStartingDestination, m.Status.Phase)
+	assert.Equal(t, 42, m.Status.MigratedKeys)
+	assert.Equal(t, 42, m.Status.DestinationKeys)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(destination), destination))
+	assert.False(t, destination.Spec.Hibernate)
+
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "vault-raft-unseal-keys"}, copied))
+	assert.Equal(t, unsealKeys.Data, copied.Data)
+
+	// The destination is active
+	destination.Status.Leader = "vault-raft-0"
+	require.NoError(t, c.Update(ctx, destination))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationCompleted, m.Status.Phase)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.True(t, source.Spec.Hibernate)
+}
+
+func TestStorageMigrationRefused(t *testing.T) {
+	ctx := context.Background()
+
+	source, destination := testVaults(false)
+	m := &vaultv1alpha1.VaultStorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "to-raft", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultStorageMigrationSpec{SourceVaultName: "vault", DestinationVaultName: "vault-raft"},
+	}
+	r, c := newTestReconciler(source, destination, m)
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(m)})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationFailed, m.Status.Phase)
+	assert.Contains(t, m.Status.Message, "hibernate: true")
+
+	// Nothing is touched
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.False(t, source.Spec.Hibernate)
+}
+
+func TestStorageMigrationIncompatibleKeystore(t *testing.T) {
+	ctx := context.Background()
+
+	source, destination := testVaults(true)
+	destination.Spec.UnsealConfig.AWS = &vaultv1alpha1.AWSUnsealConfig{KMSKeyID: "key", S3Bucket: "bucket"}
+	m := &vaultv1alpha1.VaultStorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "to-raft", Namespace: "default"},
+		Spec:       vaultv1alpha1.VaultStorageMigrationSpec{SourceVaultName: "vault", DestinationVaultName: "vault-raft"},
+	}
+	r, c := newTestReconciler(source, destination, m)
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(m)})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(m), m))
+	assert.Equal(t, vaultv1alpha1.VaultStorageMigrationFailed, m.Status.Phase)
+	assert.Contains(t, m.Status.Message, "Kubernetes Secrets")
+
+	// The source is not hibernated
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.False(t, source.Spec.Hibernate)
+}
+
+func TestDataVolume(t *testing.T) {
+	source, _ := testVaults(true)
+
+	source.Spec.Volumes[0].VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	_, _, err := dataVolume(source, "/vault/file")
+	assert.ErrorContains(t, err, "emptyDir")
+
+	_, _, err = dataVolume(source, "/vault/data")
+	assert.ErrorContains(t, err, "not on a volume")
+}